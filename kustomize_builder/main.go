@@ -1,72 +1,402 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"kustomize_builder/prompts"
 )
 
+type options struct {
+	outDir      string
+	kubeVersion string
+	kustomize   string
+	yes         bool
+	kubeContext string
+	env         string
+	preset      string
+	answers     string
+	dryRun      bool
+	plain       bool
+	tui         bool
+	format      string
+	commit      bool
+	branch      string
+	policies    dirList
+	lang        string
+}
+
+// dirList is a flag that can be given several times.
+type dirList []string
+
+func (d *dirList) String() string { return strings.Join(*d, ",") }
+
+func (d *dirList) Set(dir string) error {
+	*d = append(*d, dir)
+	return nil
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.kubeVersion, "kubernetes-version", "", "Kubernetes version to validate generated manifests against")
+	fs.StringVar(&o.kustomize, "kustomize-version", "", "kustomize version to generate kustomizations for, e.g. v4.1 for kubectl 1.22 to 1.26 (default the latest)")
+	fs.BoolVar(&o.yes, "yes", false, "apply the manifests without asking for confirmation")
+	fs.StringVar(&o.kubeContext, "context", "", "kube context to apply to with -yes (default current context)")
+	fs.StringVar(&o.env, "env", "", "overlay to apply with -yes (default the first environment)")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "print the files that would be written, with a diff against existing ones, and write nothing")
+	fs.BoolVar(&o.plain, "plain", os.Getenv("TERM") == "dumb", "ask line-based questions without colors or a pager, e.g. for CI logs and screen readers")
+	fs.BoolVar(&o.tui, "tui", false, "run the wizard full screen with the steps, answers and a live preview of the kustomizations side by side")
+	fs.StringVar(&o.format, "output-format", prompts.FormatYAML, "format of the generated manifests, yaml or json; json also prints a manifest of the generated files on stdout")
+	fs.BoolVar(&o.commit, "commit", false, "commit the written files to git without asking")
+	fs.StringVar(&o.branch, "branch", "", "branch to create for -commit (default the current branch)")
+	fs.Var(&o.policies, "policy", "directory of Rego policies to check the build output against with conftest, besides the bundled ones; may be repeated")
+	fs.StringVar(&o.lang, "lang", "", "locale of the prompts, e.g. de (default from $"+prompts.LocaleEnv+", $LC_ALL, $LC_MESSAGES or $LANG)")
+	fs.StringVar(&o.answers, "answers", "", "YAML file answering the wizard steps it lists, validated before the wizard starts")
+	fs.StringVar(&o.preset, "preset", "", "preset to start from; the application name is taken from the output directory")
+}
+
+// messages returns where to print messages for the user: stderr when the
+// manifests go to stdout, stdout otherwise.
+func (o *options) messages() io.Writer {
+	if o.format == prompts.FormatJSON {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 func main() {
+	var opts options
+	var err error
+	var command string
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	switch command {
+	case "init":
+		fs := flag.NewFlagSet("init", flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: kustomize_builder init [flags] <app-name>")
+			fs.PrintDefaults()
+		}
+		fs.StringVar(&opts.outDir, "out", "", "directory to create the skeleton in (default ./<app-name>)")
+		envs := fs.String("envs", "dev,staging,prod", "comma separated environments to create overlays for")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		if opts.outDir == "" {
+			opts.outDir = fs.Arg(0)
+		}
+		err = initTree(fs.Arg(0), strings.Split(*envs, ","), opts.outDir)
+	case "edit":
+		fs := flag.NewFlagSet("edit", flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: kustomize_builder edit [flags] <dir>")
+			fs.PrintDefaults()
+		}
+		opts.register(fs)
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		opts.outDir = fs.Arg(0)
+		prompts.Out = opts.messages()
+		err = edit(opts)
+	case "verify":
+		fs := flag.NewFlagSet("verify", flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), "Usage: kustomize_builder verify [flags] <dir>")
+			fmt.Fprintln(fs.Output(), "Only the module steps are replayed: drift in what the other steps set is not detected beyond the entries of the kustomizations.")
+			fs.PrintDefaults()
+		}
+		fs.StringVar(&opts.answers, "answers", "", "answers file the tree was generated from (default <dir>/answers.yaml)")
+		fs.StringVar(&opts.kustomize, "kustomize-version", "", "kustomize version the tree was generated for (default the answers file's, or the latest)")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		opts.outDir = fs.Arg(0)
+		err = verify(opts)
+	default:
+		flag.StringVar(&opts.outDir, "out", ".", "directory to write the generated kustomize tree to")
+		opts.register(flag.CommandLine)
+		flag.Parse()
+		prompts.Out = opts.messages()
+		var l *prompts.Layout
+		if l, err = prompts.MergeOptions(opts.outDir); err == nil {
+			if l == nil {
+				l = prompts.NewLayout("", nil)
+			}
+			err = run(opts, l)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(prompts.Out, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// initTree writes the directory skeleton of app to outDir, keeping files
+// that already exist.
+func initTree(app string, envs []string, outDir string) error {
+	if err := prompts.ValidateDNSLabel(app); err != nil {
+		return fmt.Errorf("application name %w", err)
+	}
+	for _, env := range envs {
+		if err := prompts.ValidateDNSLabel(env); err != nil {
+			return fmt.Errorf("environment %w", err)
+		}
+	}
+	files, err := prompts.Scaffold(app, envs)
+	if err != nil {
+		return err
+	}
+	var missing []prompts.File
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(outDir, f.Path)); err == nil {
+			fmt.Println("Keeping existing", filepath.Join(outDir, f.Path))
+			continue
+		}
+		missing = append(missing, f)
+	}
+	if err := prompts.WriteFiles(outDir, missing); err != nil {
+		return err
+	}
+	fmt.Printf("Skeleton of %s written to %s. Run kustomize_builder edit %s to add to it.\n", app, outDir, outDir)
+	return nil
+}
+
+// edit runs the wizard over an existing kustomize tree, with the current
+// values as defaults.
+func edit(opts options) error {
+	l, err := prompts.LoadLayout(opts.outDir)
+	if err != nil {
+		return err
+	}
+	return run(opts, l)
+}
+
+// verify renders the tree in opts.outDir again from its answers file and
+// reports the files that drifted from it, failing when any did. Only the
+// module steps are replayed.
+func verify(opts options) error {
+	answers := opts.answers
+	if answers == "" {
+		answers = filepath.Join(opts.outDir, prompts.AnswersFileName)
+	}
+	files, err := prompts.RenderAnswers(answers, opts.kustomize)
+	if err != nil {
+		return err
+	}
+	drifts, err := prompts.Verify(opts.outDir, files)
+	if err != nil {
+		return err
+	}
+	for _, d := range drifts {
+		fmt.Println(d)
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("%s drifted from %s in %d places", opts.outDir, answers, len(drifts))
+	}
+	fmt.Println(opts.outDir, "matches", answers)
+	return nil
+}
+
+func run(opts options, l *prompts.Layout) error {
+	if err := prompts.ValidateFormat(opts.format); err != nil {
+		return err
+	}
+	prompts.Plain = opts.plain
+	prompts.TUI = opts.tui && !opts.plain
+	if opts.lang != "" {
+		if err := prompts.SetLocale(opts.lang); err != nil {
+			return err
+		}
+	} else {
+		// Locales of the environment without translations keep English.
+		_ = prompts.SetLocale(prompts.LocaleFromEnv())
+	}
+	s := &prompts.State{OutDir: opts.outDir, Layout: l}
+	if opts.answers != "" {
+		answers, err := prompts.LoadAnswers(opts.answers)
+		if err != nil {
+			return err
+		}
+		answers.Apply(s)
+	}
+	if opts.preset != "" {
+		preset, err := prompts.FindPreset(opts.preset)
+		if err != nil {
+			return err
+		}
+		if l.App == "" {
+			abs, err := filepath.Abs(opts.outDir)
+			if err != nil {
+				return err
+			}
+			l.App = filepath.Base(abs)
+		}
+		if err := preset.Apply(s); err != nil {
+			return err
+		}
+		s.Skip["Application"] = true
+		s.Skip["Preset"] = true
+	}
+	wizard := &prompts.Wizard{Steps: prompts.DefaultSteps()}
+	if err := wizard.Run(s); err != nil {
+		return err
+	}
+	l = s.Layout
+
+	var err error
+	kustomize := opts.kustomize
+	if kustomize == "" {
+		kustomize = l.Kustomize
+	}
+	if kustomize == "" {
+		kustomize = prompts.KustomizeVersions[0].Name
+		if prompts.Interactive() {
+			if kustomize, err = prompts.KustomizeVersionPrompt(l); err != nil {
+				return err
+			}
+		}
+	}
+	if err := prompts.CompatOptions(l, kustomize); err != nil {
+		return err
+	}
+
+	kubeVersion := opts.kubeVersion
+	if kubeVersion == "" {
+		kubeVersion = prompts.KubernetesVersions[0]
+		if prompts.Interactive() {
+			if kubeVersion, err = prompts.KubernetesVersionPrompt(); err != nil {
+				return err
+			}
+		}
+	}
+	proceed, err := prompts.ValidateOptions(l, kubeVersion)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		fmt.Fprintln(prompts.Out, "Nothing written.")
+		return nil
+	}
+
+	rendered, err := prompts.BuildOverlays(opts.outDir, l)
+	if err != nil {
+		return fmt.Errorf("kustomize build: %w", err)
+	}
+	fixed, err := prompts.LintOptions(l, rendered)
+	if err != nil {
+		return err
+	}
+	if fixed {
+		if rendered, err = prompts.BuildOverlays(opts.outDir, l); err != nil {
+			return fmt.Errorf("kustomize build: %w", err)
+		}
+	}
+	if prompts.Interactive() && !opts.dryRun {
+		write, err := prompts.PreviewOptions(l, rendered)
+		if err != nil {
+			return err
+		}
+		if !write {
+			fmt.Fprintln(prompts.Out, "Nothing written.")
+			return nil
+		}
+	}
+
+	all, err := l.Render()
+	if err != nil {
+		return err
+	}
+	if opts.format == prompts.FormatJSON {
+		if all, err = prompts.ToJSON(all); err != nil {
+			return err
+		}
+	}
+	// The answers let verify check the tree and a later run repeat it.
+	answers, err := s.AnswersFile().Marshal()
+	if err != nil {
+		return err
+	}
+	all = append(all, prompts.File{Path: prompts.AnswersFileName, Content: answers})
+	var manifest *prompts.Manifest
+	if opts.format == prompts.FormatJSON {
+		if manifest, err = prompts.NewManifest(opts.outDir, opts.format, all); err != nil {
+			return err
+		}
+	}
+	if opts.dryRun {
+		if manifest != nil {
+			return manifest.Write(os.Stdout)
+		}
+		return prompts.PlanFiles(os.Stdout, opts.outDir, all)
+	}
+	if err := prompts.WriteFiles(opts.outDir, all); err != nil {
+		return err
+	}
+	if manifest != nil {
+		manifest.Written = true
+		if err := manifest.Write(os.Stdout); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(prompts.Out, "Kustomize tree written to", opts.outDir)
+
+	commit, branch := opts.commit, opts.branch
+	if !commit && prompts.Interactive() {
+		if commit, branch, err = prompts.GitOptions(l, opts.outDir); err != nil {
+			return err
+		}
+	}
+	if commit {
+		message, err := prompts.CommitMessage(l)
+		if err != nil {
+			return err
+		}
+		if err := prompts.CommitFiles(prompts.Out, opts.outDir, branch, all, message); err != nil {
+			return err
+		}
+	}
 
-	prompts.IstioOptions()
-
-	// Ask the user to enter some text
-	// fmt.Println("Select an option:")
-	// fmt.Println("1. Private")
-	// fmt.Println("2. Public")
-	// fmt.Println("3. Public and private")
-
-	// fmt.Print("Enter your choice: ")
-	// var choice string
-	// fmt.Scanln(&choice) // Read user input from the console
-
-	// switch choice {
-	// case "1":
-	// 	fmt.Println("You selected Option 1.")
-	// 	// Perform action for Option 1
-	// case "2":
-	// 	fmt.Println("You selected Option 2.")
-	// 	// Perform action for Option 2
-	// case "3":
-	// 	fmt.Println("You selected Option 3.")
-	// 	// Perform action for Option 3
-	// default:
-	// 	fmt.Println("Invalid choice. Please select a valid option.")
-	// }
-
-	// // Read user input from the console
-	// scanner := bufio.NewScanner(os.Stdin)
-	// scanner.Scan() // use `Scan` to read the next token from input
-
-	// // Get the user input text
-	// userInput1 := scanner.Text()
-	// userInput2
-
-	// // Open a file in write-only mode. Create the file if it does not exist.
-	// file, err := os.Create("output.txt")
-	// if err != nil {
-	// 	fmt.Println("Error:", err)
-	// 	return
-	// }
-	// defer file.Close() // defer closing the file until the surrounding function returns
-
-	// // Write the user input to the file
-	// _, err = file.WriteString(userInput1)
-	// if err != nil {
-	// 	fmt.Println("Error:", err)
-	// 	return
-	// }
-
-	// fmt.Println("Data has been written to the file 'output.txt'.")
-
-	// /////
-
-	// prompts.MyFunction()
-
-	// // Using the struct from the module
-	// instance := prompts.MyStruct{
-	// 	Field1: 42,
-	// 	Field2: "Hello",
-	// }
-	// fmt.Println("Struct Field1:", instance.Field1)
-	// fmt.Println("Struct Field2:", instance.Field2)
+	denied, err := prompts.PolicyOptions(rendered, opts.policies, opts.yes)
+	if err != nil {
+		return err
+	}
+	if denied {
+		if opts.yes {
+			return fmt.Errorf("not applying: the manifests break policies that deny them")
+		}
+		fmt.Fprintln(prompts.Out, "Applying is blocked until the denied policies are fixed.")
+		return nil
+	}
 
+	target := prompts.ApplyTarget{Env: opts.env, Context: opts.kubeContext, Namespace: l.Base.Namespace}
+	if target.Env == "" {
+		target.Env = l.Envs[0]
+	}
+	apply := opts.yes
+	if !opts.yes && prompts.Interactive() {
+		if target, apply, err = prompts.ApplyOptions(l); err != nil || !apply {
+			return err
+		}
+		if apply, err = prompts.ConfirmApply(target); err != nil {
+			return err
+		}
+	}
+	if !apply {
+		return nil
+	}
+	if _, ok := rendered[target.Env]; !ok {
+		return fmt.Errorf("no overlay for environment %q", target.Env)
+	}
+	return prompts.Apply(target, rendered[target.Env], filepath.Join(opts.outDir, prompts.OverlayDir(target.Env)))
 }
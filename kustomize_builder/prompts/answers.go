@@ -0,0 +1,435 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"gopkg.in/yaml.v3"
+)
+
+// AnswersVersion is the version of the answers file schema this builder
+// reads.
+const AnswersVersion = 1
+
+// AnswersFile pre-answers wizard steps, for repeatable runs:
+//
+//	version: 1
+//	app: shop
+//	environments: [dev, prod]
+//	namespace: shop
+//	kustomize: v4.1
+//	modules:
+//	  Routing:
+//	    backend: Istio
+//	    exposure: [Public]
+//	    publicHosts: shop.example.com
+//
+// Module answers are keyed by module and question name, and use the option
+// text for selections. Listing an optional module opts into it. Prompts
+// answers the prompts modules ask while generating, such as the mTLS mode
+// of each environment, by message and written as in Plain mode; verify
+// replays them, while the wizard still asks them.
+type AnswersFile struct {
+	Version      int
+	App          string
+	Environments []string
+	// Namespace and Kustomize are the namespace and kustomize version the
+	// tree was generated for, which the module steps render with.
+	Namespace string `yaml:",omitempty"`
+	Kustomize string `yaml:",omitempty"`
+	// Modules holds the answers of the listed modules as their prompts
+	// would have given them.
+	Modules map[string]map[string]interface{} `yaml:",omitempty"`
+	Prompts map[string]string                 `yaml:",omitempty"`
+}
+
+// AnswersFileName is the file run saves the answers of a wizard run to, in
+// the output directory, and verify reads them from.
+const AnswersFileName = "answers.yaml"
+
+// AnswersFile returns the answers s was given, to run the wizard again with
+// them or to verify the tree against them.
+func (s *State) AnswersFile() *AnswersFile {
+	return &AnswersFile{
+		Version:      AnswersVersion,
+		App:          s.Layout.App,
+		Environments: s.Layout.Envs,
+		Namespace:    s.Layout.Base.Namespace,
+		Kustomize:    s.Layout.Kustomize,
+		Modules:      s.Given.Modules,
+		Prompts:      s.Given.Prompts,
+	}
+}
+
+// Marshal returns the answers file as YAML.
+func (a *AnswersFile) Marshal() ([]byte, error) {
+	return marshalYAML(a)
+}
+
+// given records the answers of m in s as an answers file lists them.
+func (s *State) given(m PromptModule, values map[string]interface{}) {
+	listed := map[string]interface{}{}
+	for name, v := range values {
+		switch v := v.(type) {
+		case core.OptionAnswer:
+			listed[name] = v.Value
+		case []core.OptionAnswer:
+			options := make([]string, len(v))
+			for i, o := range v {
+				options[i] = o.Value
+			}
+			listed[name] = options
+		default:
+			listed[name] = v
+		}
+	}
+	if s.Given.Modules == nil {
+		s.Given.Modules = map[string]map[string]interface{}{}
+	}
+	s.Given.Modules[m.Name()] = listed
+}
+
+// recorded, when set, records the answers to the prompts asked by message,
+// written as in Plain mode.
+var recorded map[string]string
+
+// record records the answer to p in recorded.
+func record(p survey.Prompt, response interface{}) {
+	message, _, _ := plainPrompt(p)
+	switch r := response.(type) {
+	case *string:
+		recorded[message] = *r
+	case *bool:
+		recorded[message] = "n"
+		if *r {
+			recorded[message] = "y"
+		}
+	case *int:
+		if s, ok := p.(*survey.Select); ok && *r >= 0 && *r < len(s.Options) {
+			recorded[message] = s.Options[*r]
+		}
+	}
+}
+
+// AnswersError lists the problems found in an answers file.
+type AnswersError struct {
+	File     string
+	Problems []AnswersProblem
+}
+
+// AnswersProblem is a problem with the value at Path, such as
+// modules.Routing.tls.
+type AnswersProblem struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (e *AnswersError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid answers file %s:", e.File)
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "\n  %s:%d: %s: %s", e.File, p.Line, p.Path, p.Message)
+	}
+	return b.String()
+}
+
+// LoadAnswers reads the answers file at path and validates it against the
+// registered modules, reporting all problems at once.
+func LoadAnswers(path string) (*AnswersFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode, Line: 1}
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+
+	var v answersValidator
+	a := v.file(root)
+	if len(v.problems) > 0 {
+		sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+		return nil, &AnswersError{File: path, Problems: v.problems}
+	}
+	return a, nil
+}
+
+// Apply answers the steps of s the file covers. Module steps still run,
+// taking their answers from the file.
+func (a *AnswersFile) Apply(s *State) {
+	if s.Skip == nil {
+		s.Skip = map[string]bool{}
+	}
+	if a.App != "" {
+		s.Layout.App = a.App
+		s.Skip["Application"] = true
+	}
+	if len(a.Environments) > 0 {
+		s.Layout.SetEnvs(a.Environments)
+		s.Skip["Environments"] = true
+	}
+	if a.Namespace != "" {
+		s.Layout.Base.Namespace = a.Namespace
+	}
+	if v, err := FindKustomizeVersion(a.Kustomize); err == nil {
+		s.Layout.Kustomize = v.Name
+	}
+	s.Answers = a
+}
+
+// module returns the answers of the named module, if the file lists it.
+func (a *AnswersFile) module(name string) (map[string]interface{}, bool) {
+	if a == nil {
+		return nil, false
+	}
+	values, ok := a.Modules[name]
+	return values, ok
+}
+
+type answersValidator struct {
+	problems []AnswersProblem
+}
+
+func (v *answersValidator) report(n *yaml.Node, path, format string, args ...interface{}) {
+	v.problems = append(v.problems, AnswersProblem{Line: n.Line, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *answersValidator) file(root *yaml.Node) *AnswersFile {
+	a := &AnswersFile{}
+	fields, ok := v.fields(root, "", []string{"version", "app", "environments", "namespace", "kustomize", "modules", "prompts"})
+	if !ok {
+		return a
+	}
+
+	if n := fields["version"]; n == nil {
+		v.report(root, "version", "missing, set it to %d", AnswersVersion)
+	} else if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+		v.report(n, "version", "expected an integer, got %s", kindName(n))
+	} else if a.Version, _ = strconv.Atoi(n.Value); a.Version != AnswersVersion {
+		v.report(n, "version", "unsupported version %s, this builder reads version %d", n.Value, AnswersVersion)
+	}
+
+	if n := fields["app"]; n != nil {
+		a.App = v.string(n, "app", ValidateDNSLabel)
+	}
+	if n := fields["environments"]; n != nil {
+		if n.Kind != yaml.SequenceNode {
+			v.report(n, "environments", "expected a list, got %s", kindName(n))
+		} else if len(n.Content) == 0 {
+			v.report(n, "environments", "at least one environment is required")
+		}
+		for i, item := range n.Content {
+			a.Environments = append(a.Environments, v.string(item, fmt.Sprintf("environments[%d]", i), ValidateDNSLabel))
+		}
+	}
+
+	if n := fields["namespace"]; n != nil {
+		a.Namespace = v.string(n, "namespace", ValidateDNSLabel)
+	}
+	if n := fields["kustomize"]; n != nil {
+		a.Kustomize = v.string(n, "kustomize", func(ans interface{}) error {
+			_, err := FindKustomizeVersion(answer(ans))
+			return err
+		})
+	}
+
+	if n := fields["modules"]; n != nil {
+		var names []string
+		for _, m := range Modules() {
+			names = append(names, m.Name())
+		}
+		listed, ok := v.fields(n, "modules", names)
+		if !ok {
+			return a
+		}
+		a.Modules = map[string]map[string]interface{}{}
+		for _, m := range Modules() {
+			if n, ok := listed[m.Name()]; ok {
+				a.Modules[m.Name()] = v.module(m, n, joinPath("modules", m.Name()))
+			}
+		}
+	}
+
+	if n := fields["prompts"]; n != nil {
+		if n.Kind != yaml.MappingNode {
+			v.report(n, "prompts", "expected a mapping, got %s", kindName(n))
+			return a
+		}
+		a.Prompts = map[string]string{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if value.Kind != yaml.ScalarNode || value.Tag == "!!null" {
+				v.report(value, joinPath("prompts", key.Value), "expected a string, got %s", kindName(value))
+				continue
+			}
+			a.Prompts[key.Value] = value.Value
+		}
+	}
+	return a
+}
+
+// fields returns the values of mapping n by key, reporting keys not in
+// known.
+func (v *answersValidator) fields(n *yaml.Node, path string, known []string) (map[string]*yaml.Node, bool) {
+	if n.Kind != yaml.MappingNode {
+		if path == "" {
+			path = "."
+		}
+		v.report(n, path, "expected a mapping, got %s", kindName(n))
+		return nil, false
+	}
+	fields := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		p := joinPath(path, key.Value)
+		switch {
+		case !containsString(known, key.Value):
+			v.report(key, p, "unknown key, expected one of: %s", strings.Join(known, ", "))
+		case fields[key.Value] != nil:
+			v.report(key, p, "duplicate key")
+		default:
+			fields[key.Value] = value
+		}
+	}
+	return fields, true
+}
+
+func (v *answersValidator) string(n *yaml.Node, path string, validate survey.Validator) string {
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		v.report(n, path, "expected a string, got %s", kindName(n))
+		return ""
+	}
+	if err := validate(n.Value); err != nil {
+		v.report(n, path, "%v", err)
+	}
+	return n.Value
+}
+
+// module validates the answers to m's questions, defaulting the missing
+// ones as the prompts would.
+func (v *answersValidator) module(m PromptModule, n *yaml.Node, path string) map[string]interface{} {
+	values := map[string]interface{}{}
+	qs := m.Questions()
+	var names []string
+	for _, q := range qs {
+		names = append(names, q.Name)
+	}
+	fields, ok := v.fields(n, path, names)
+	if !ok {
+		return values
+	}
+	for _, q := range qs {
+		var ans interface{}
+		var err error
+		node := fields[q.Name]
+		if node == nil {
+			node = n
+			ans, err = plainAnswer(q.Prompt, "")
+			if err == nil && q.Validate != nil {
+				if err = q.Validate(ans); err != nil {
+					err = fmt.Errorf("required answer is missing: %v", err)
+				}
+			}
+		} else {
+			ans, err = fileAnswer(q.Prompt, node)
+			if err == nil && q.Validate != nil {
+				err = q.Validate(ans)
+			}
+		}
+		if err != nil {
+			v.report(node, joinPath(path, q.Name), "%v", err)
+			continue
+		}
+		values[q.Name] = ans
+	}
+	return values
+}
+
+// fileAnswer converts n to the answer survey would give for p.
+func fileAnswer(p survey.Prompt, n *yaml.Node) (interface{}, error) {
+	switch p := p.(type) {
+	case *survey.Confirm:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+			return nil, fmt.Errorf("expected true or false, got %s", kindName(n))
+		}
+		var b bool
+		err := n.Decode(&b)
+		return b, err
+	case *survey.Select:
+		if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+			return nil, fmt.Errorf("expected one of: %s, got %s", strings.Join(p.Options, ", "), kindName(n))
+		}
+		return fileOption(p.Options, n.Value)
+	case *survey.MultiSelect:
+		if n.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("expected a list of: %s, got %s", strings.Join(p.Options, ", "), kindName(n))
+		}
+		answers := []core.OptionAnswer{}
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("expected a list of: %s, got a list of %s", strings.Join(p.Options, ", "), kindName(item))
+			}
+			o, err := fileOption(p.Options, item.Value)
+			if err != nil {
+				return nil, err
+			}
+			answers = append(answers, o)
+		}
+		return answers, nil
+	}
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return nil, fmt.Errorf("expected a string, got %s", kindName(n))
+	}
+	return n.Value, nil
+}
+
+func fileOption(options []string, s string) (core.OptionAnswer, error) {
+	i := indexOf(options, s)
+	if i < 0 {
+		return core.OptionAnswer{}, fmt.Errorf("%q is not one of: %s", s, strings.Join(options, ", "))
+	}
+	return core.OptionAnswer{Value: s, Index: i}, nil
+}
+
+// kindName describes the type of n for error messages.
+func kindName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.AliasNode:
+		return "an alias"
+	}
+	switch n.Tag {
+	case "!!bool":
+		return "a boolean"
+	case "!!int", "!!float":
+		return "a number"
+	case "!!null":
+		return "nothing"
+	}
+	return strconv.Quote(n.Value)
+}
+
+// joinPath appends key to the dotted path, quoting keys that are not plain
+// words.
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, " .[]\"") {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
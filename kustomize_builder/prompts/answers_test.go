@@ -0,0 +1,94 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnswersFileRendersTheSameTree(t *testing.T) {
+	var steps []Step
+	for _, m := range Modules() {
+		steps = append(steps, ModuleStep(m))
+	}
+	for _, run := range goldenRuns {
+		t.Run(run.name, func(t *testing.T) {
+			s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev", "prod"})}
+			sc := &Script{Answers: run.answers, Defaults: true}
+			want, err := sc.Wizard(s, steps)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			answers, err := s.AnswersFile().Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(s.OutDir, AnswersFileName)
+			if err := os.WriteFile(path, answers, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			files, err := RenderAnswers(path, "")
+			if err != nil {
+				t.Fatalf("%v\n%s", err, answers)
+			}
+			if !reflect.DeepEqual(files, want) {
+				t.Errorf("answers render a different tree:\n%s", answers)
+			}
+		})
+	}
+}
+
+func TestVerifyFindsNoDriftInAWrittenTree(t *testing.T) {
+	var steps []Step
+	for _, m := range Modules() {
+		steps = append(steps, ModuleStep(m))
+	}
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		kustomize string
+		answers   map[string]string
+	}{
+		{"rbac in a namespace", "shop-system", "v4.0", map[string]string{
+			"Run the app with a dedicated ServiceAccount?": "y",
+			"API permissions:": "Read ConfigMaps",
+		}},
+		{"bases of kustomize v2", "", "v2.0", map[string]string{
+			"Generate NetworkPolicies?": "y",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := &State{OutDir: dir, Layout: NewLayout("shop", []string{"dev", "prod"})}
+			s.Layout.Base.Namespace = tc.namespace
+			s.Layout.Kustomize = tc.kustomize
+			sc := &Script{Answers: tc.answers, Defaults: true}
+			files, err := sc.Wizard(s, steps)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			answers, err := s.AnswersFile().Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, File{Path: AnswersFileName, Content: answers})
+			if err := WriteFiles(dir, files); err != nil {
+				t.Fatal(err)
+			}
+
+			rendered, err := RenderAnswers(filepath.Join(dir, AnswersFileName), "")
+			if err != nil {
+				t.Fatalf("%v\n%s", err, answers)
+			}
+			drifts, err := Verify(dir, rendered)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range drifts {
+				t.Errorf("drift: %s", d)
+			}
+		})
+	}
+}
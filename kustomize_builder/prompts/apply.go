@@ -0,0 +1,129 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// FieldManager is the field manager used for server-side apply.
+const FieldManager = "kustomize-builder"
+
+// ApplyTarget is the overlay, cluster and namespace built manifests are
+// applied to.
+type ApplyTarget struct {
+	Env       string
+	Context   string
+	Namespace string
+}
+
+func (t ApplyTarget) kubectlArgs() []string {
+	var args []string
+	if t.Context != "" {
+		args = append(args, "--context", t.Context)
+	}
+	if t.Namespace != "" {
+		args = append(args, "--namespace", t.Namespace)
+	}
+	return args
+}
+
+func (t ApplyTarget) String() string {
+	ctx := t.Context
+	if ctx == "" {
+		ctx = "current context"
+	}
+	if t.Namespace == "" {
+		return ctx
+	}
+	return ctx + "/" + t.Namespace
+}
+
+func kubeContexts() ([]string, string, error) {
+	out, err := exec.Command("kubectl", "config", "get-contexts", "-o", "name").Output()
+	if err != nil {
+		return nil, "", fmt.Errorf("listing kube contexts: %w", err)
+	}
+	current, _ := exec.Command("kubectl", "config", "current-context").Output()
+	return strings.Fields(string(out)), strings.TrimSpace(string(current)), nil
+}
+
+// ApplyOptions asks whether to apply the build output, of which overlay and
+// to which context and namespace. It returns false when nothing should be
+// applied.
+func ApplyOptions(l *Layout) (ApplyTarget, bool, error) {
+	target := ApplyTarget{Env: l.Envs[0], Namespace: l.Base.Namespace}
+	var apply bool
+	if err := askOne(&survey.Confirm{Message: "Apply the manifests to a cluster?"}, &apply); err != nil || !apply {
+		return target, false, err
+	}
+	if len(l.Envs) > 1 {
+		err := askOne(&survey.Select{Message: "Overlay to apply:", Options: l.Envs}, &target.Env)
+		if err != nil {
+			return target, false, err
+		}
+	}
+
+	contexts, current, err := kubeContexts()
+	if err != nil {
+		return target, false, err
+	}
+	if len(contexts) == 0 {
+		return target, false, fmt.Errorf("no kube contexts configured")
+	}
+	err = askOne(&survey.Select{
+		Message: "Kube context:",
+		Options: contexts,
+		Default: current,
+	}, &target.Context)
+	if err != nil {
+		return target, false, err
+	}
+	err = askOne(&survey.Input{
+		Message: "Namespace:",
+		Default: target.Namespace,
+		Help:    "Used for objects without a namespace. Leave empty for the context's default.",
+	}, &target.Namespace)
+	return target, true, err
+}
+
+// ConfirmApply asks for final confirmation before applying.
+func ConfirmApply(target ApplyTarget) (bool, error) {
+	var ok bool
+	err := askOne(&survey.Confirm{
+		Message: "Server-side apply " + OverlayDir(target.Env) + " to " + target.String() + "?",
+	}, &ok)
+	return ok, err
+}
+
+// Apply server-side applies rendered to the target. dir is the directory of
+// the target's overlay and is only used in rollback instructions.
+func Apply(target ApplyTarget, rendered []byte, dir string) error {
+	args := append(target.kubectlArgs(), "apply", "--server-side", "--field-manager", FieldManager, "-f", "-")
+	var stderr bytes.Buffer
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdin = bytes.NewReader(rendered)
+	cmd.Stdout = Out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprint(os.Stderr, stderr.String())
+		printRollback(target, dir)
+		return fmt.Errorf("kubectl apply: %w", err)
+	}
+	return nil
+}
+
+func printRollback(target ApplyTarget, dir string) {
+	kubectl := strings.Join(append([]string{"kubectl"}, target.kubectlArgs()...), " ")
+	fmt.Fprintln(Out)
+	fmt.Fprintln(Out, "The apply failed; objects reported as serverside-applied above were changed.")
+	fmt.Fprintln(Out, "To roll back objects that existed before, check out the previous revision of", dir, "and run:")
+	build := "kubectl kustomize --load-restrictor LoadRestrictionsNone " + dir
+	fmt.Fprintf(Out, "    %s | %s apply --server-side --field-manager %s -f -\n", build, kubectl, FieldManager)
+	fmt.Fprintln(Out, "To remove everything this kustomization defines, run:")
+	fmt.Fprintf(Out, "    %s | %s delete -f -\n", build, kubectl)
+}
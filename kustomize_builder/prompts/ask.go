@@ -0,0 +1,74 @@
+package prompts
+
+import (
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// askList keeps asking for values until an empty answer is given.
+func askList(message, help string, validate survey.Validator) ([]string, error) {
+	var values []string
+	for {
+		var value string
+		opts := []survey.AskOpt{}
+		if validate != nil {
+			opts = append(opts, survey.WithValidator(Optional(validate)))
+		}
+		err := askOne(&survey.Input{
+			Message: message + " (empty to finish)",
+			Help:    help,
+		}, &value, opts...)
+		if err != nil {
+			return nil, err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return values, nil
+		}
+		values = append(values, value)
+	}
+}
+
+// splitList splits a comma separated answer.
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// keepEntries lets the user deselect existing entries of a list field.
+func keepEntries[T any](message string, entries []T, name func(T) string) ([]T, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	options := make([]string, len(entries))
+	for i, e := range entries {
+		options[i] = name(e)
+	}
+	var selected []int
+	err := askOne(&survey.MultiSelect{
+		Message: message,
+		Options: options,
+		Default: options,
+	}, &selected)
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]T, 0, len(selected))
+	for _, i := range selected {
+		kept = append(kept, entries[i])
+	}
+	return kept, nil
+}
+
+// askMore asks a yes/no question, defaulting to no.
+func askMore(message string) (bool, error) {
+	var more bool
+	err := askOne(&survey.Confirm{Message: message}, &more)
+	return more, err
+}
@@ -0,0 +1,157 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Topology domains pods can be spread across.
+var spreadKeys = map[string]string{
+	"Zones": "topology.kubernetes.io/zone",
+	"Nodes": "kubernetes.io/hostname",
+}
+
+// availabilityModule keeps the app available through voluntary disruptions
+// and zone or node failures, with a PodDisruptionBudget for its Deployments
+// and StatefulSets and topology spread constraints on all its workloads.
+type availabilityModule struct{}
+
+func (availabilityModule) Name() string { return "Availability" }
+
+func (availabilityModule) OptIn() string {
+	return "Generate a PodDisruptionBudget and topology spread constraints?"
+}
+
+// validateIntOrPercent accepts a non-negative count or a percentage.
+func validateIntOrPercent(ans interface{}) error {
+	s := answer(ans)
+	n, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	if err != nil || n < 0 || (strings.HasSuffix(s, "%") && n > 100) {
+		return fmt.Errorf("%q is neither a count nor a percentage", s)
+	}
+	return nil
+}
+
+func (availabilityModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "budget",
+			Prompt: &survey.Select{
+				Message: "Disruption budget:",
+				Options: []string{"minAvailable", "maxUnavailable"},
+				Help:    "minAvailable pods are kept running, or at most maxUnavailable are evicted at once, e.g. during node drains.",
+			},
+		},
+		{
+			Name:     "budgetValue",
+			Prompt:   &survey.Input{Message: "Pods, as a count or percentage:", Default: "1"},
+			Validate: validateIntOrPercent,
+		},
+		{
+			Name: "spread",
+			Prompt: &survey.MultiSelect{
+				Message: "Spread pods across:",
+				Options: []string{"Zones", "Nodes"},
+				Default: []string{"Zones"},
+			},
+		},
+		{
+			Name:     "maxSkew",
+			Prompt:   &survey.Input{Message: "Maximum pod count difference between domains:", Default: "1"},
+			Validate: ValidatePositiveInt,
+		},
+		{
+			Name: "strict",
+			Prompt: &survey.Confirm{
+				Message: "Keep pods pending rather than violate the spread?",
+				Help:    "Sets whenUnsatisfiable to DoNotSchedule instead of ScheduleAnyway.",
+			},
+		},
+	}
+}
+
+// Asks leaves out the disruption budget when the app only runs CronJobs and
+// Jobs, whose pods run to completion.
+func (availabilityModule) Asks(name string, answers Answers) bool {
+	switch name {
+	case "budget", "budgetValue":
+		kinds, err := workloadKinds(answers.Layout, answers.BaseDir)
+		return err != nil || keepsReplicas(kinds)
+	}
+	return true
+}
+
+// keepsReplicas reports whether any of kinds keeps replicas running.
+func keepsReplicas(kinds []string) bool {
+	for _, kind := range kinds {
+		if runsReplicas(kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func (availabilityModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	kinds, err := workloadKinds(l, answers.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+	var files []File
+	if keepsReplicas(kinds) {
+		var budget interface{} = answers.String("budgetValue")
+		if n, err := strconv.Atoi(answers.String("budgetValue")); err == nil {
+			budget = n
+		}
+		pdb, err := marshalYAML(map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata":   map[string]interface{}{"name": l.App},
+			"spec": map[string]interface{}{
+				answers.String("budget"): budget,
+				"selector":               map[string]interface{}{"matchLabels": appLabels(l.App)},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, "pdb.yaml"), Content: pdb})
+		l.Base.AddResource("pdb.yaml")
+	}
+
+	spread := answers.Strings("spread")
+	if len(spread) == 0 {
+		return files, nil
+	}
+	maxSkew, err := strconv.Atoi(answers.String("maxSkew"))
+	if err != nil {
+		return nil, err
+	}
+	whenUnsatisfiable := "ScheduleAnyway"
+	if answers.Bool("strict") {
+		whenUnsatisfiable = "DoNotSchedule"
+	}
+	var constraints []interface{}
+	for _, domain := range spread {
+		constraints = append(constraints, map[string]interface{}{
+			"maxSkew":           maxSkew,
+			"topologyKey":       spreadKeys[domain],
+			"whenUnsatisfiable": whenUnsatisfiable,
+			"labelSelector":     map[string]interface{}{"matchLabels": appLabels(l.App)},
+		})
+	}
+	for _, kind := range kinds {
+		patch, err := marshalYAML(podSpecPatch(kind, map[string]interface{}{"topologySpreadConstraints": constraints}))
+		if err != nil {
+			return nil, err
+		}
+		file := "patches/topology-spread-" + strings.ToLower(kind) + ".yaml"
+		l.Base.AddPatch(Patch{Path: file, Target: &Selector{Kind: kind}})
+		files = append(files, File{Path: path.Join(BaseDir, file), Content: patch})
+	}
+	return files, nil
+}
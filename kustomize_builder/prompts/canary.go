@@ -0,0 +1,129 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Subset is a version of the app receiving a share of its traffic.
+type Subset struct {
+	Name   string
+	Weight int
+}
+
+// parseSubsets parses name=weight pairs whose weights sum to 100.
+func parseSubsets(s string) ([]Subset, error) {
+	var subsets []Subset
+	var total int
+	for _, pair := range splitList(s) {
+		name, weight, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("subset %q must be of the form name=weight", pair)
+		}
+		if err := ValidateDNSLabel(name); err != nil {
+			return nil, err
+		}
+		w, err := strconv.Atoi(weight)
+		if err != nil || w < 0 || w > 100 {
+			return nil, fmt.Errorf("weight of %s must be a number from 0 to 100", name)
+		}
+		subsets = append(subsets, Subset{Name: name, Weight: w})
+		total += w
+	}
+	if len(subsets) < 2 {
+		return nil, fmt.Errorf("at least two subsets are needed to split traffic")
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("weights sum to %d instead of 100", total)
+	}
+	return subsets, nil
+}
+
+func validateSubsets(ans interface{}) error {
+	_, err := parseSubsets(answer(ans))
+	return err
+}
+
+// canaryModule splits the app's traffic between versions by weight, with a
+// DestinationRule defining a subset per version. It runs before the Istio
+// module, which weights the gateway routes the same way. The workloads of
+// the versions are the user's to deploy, each with its pods labeled with the
+// subset name; a subset without them has no endpoints.
+type canaryModule struct{}
+
+func (canaryModule) Name() string { return "Canary" }
+
+func (canaryModule) Applies(l *Layout) bool { return usesBackend(l, BackendIstio) }
+
+func (canaryModule) OptIn() string { return "Split traffic between versions of the app?" }
+
+func (canaryModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "subsets",
+			Prompt: &survey.Input{
+				Message: "Subsets and weights:",
+				Default: "stable=90,canary=10",
+				Help:    "Comma separated name=weight pairs. The weights must sum to 100. Each subset needs a workload of its own, whose pods carry its name in the label asked next.",
+			},
+			Validate: validateSubsets,
+		},
+		{
+			Name:     "label",
+			Prompt:   &survey.Input{Message: "Pod label holding the subset name:", Default: "version"},
+			Validate: ValidateLabelKey,
+		},
+	}
+}
+
+func (canaryModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	subsets, err := parseSubsets(answers.String("subsets"))
+	if err != nil {
+		return nil, err
+	}
+	var defs []interface{}
+	for _, s := range subsets {
+		defs = append(defs, map[string]interface{}{
+			"name":   s.Name,
+			"labels": map[string]interface{}{answers.String("label"): s.Name},
+		})
+	}
+
+	var files []File
+	add := func(file string, obj map[string]interface{}) error {
+		content, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, file), Content: content})
+		l.Base.AddResource(file)
+		return nil
+	}
+	err = add("destinationrule-"+l.App+".yaml", map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "DestinationRule",
+		"metadata":   map[string]interface{}{"name": l.App},
+		"spec":       map[string]interface{}{"host": l.App, "subsets": defs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Traffic from within the mesh is split by a VirtualService bound to
+	// the sidecars rather than a gateway.
+	if err := add("virtualservice-"+l.App+"-mesh.yaml", virtualService(l.App+"-mesh", l.App, []string{l.App}, "mesh", subsets)); err != nil {
+		return nil, err
+	}
+	l.Routing.Subsets = subsets
+	var selectors []string
+	for _, s := range subsets {
+		selectors = append(selectors, answers.String("label")+"="+s.Name)
+	}
+	note("Warning: the subsets select pods labeled " + strings.Join(selectors, ", ") +
+		". Deploy a workload per subset with its pods labeled so, or the routes to a subset have no endpoints.")
+	return files, nil
+}
@@ -0,0 +1,25 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanaryWarnsAboutTheSubsetLabels(t *testing.T) {
+	l := NewLayout("shop", []string{"dev"})
+	l.Routing = Routing{Backend: BackendIstio, Public: true}
+	sc := &Script{}
+	err := sc.Run(func() error {
+		_, err := canaryModule{}.Generate(Answers{Layout: l, Values: map[string]interface{}{
+			"subsets": "stable=90,canary=10",
+			"label":   "track",
+		}})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sc.Transcript.String(), "track=stable, track=canary") {
+		t.Errorf("no warning about the subset labels:\n%s", sc.Transcript.String())
+	}
+}
@@ -0,0 +1,166 @@
+package prompts
+
+import (
+	"fmt"
+	"net/mail"
+	"path"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+const (
+	issuerACME       = "ACME (Let's Encrypt)"
+	issuerSelfSigned = "Self-signed"
+
+	letsEncryptServer = "https://acme-v02.api.letsencrypt.org/directory"
+)
+
+// certManagerModule generates the cert-manager Certificate, and optionally
+// its issuer, for the TLS secret the routing backends terminate TLS with.
+type certManagerModule struct{}
+
+func (certManagerModule) Name() string { return "cert-manager" }
+
+func (certManagerModule) Applies(l *Layout) bool {
+	return l.Routing.TLS && len(l.Routing.Exposures()) > 0
+}
+
+func validateEmail(ans interface{}) error {
+	if _, err := mail.ParseAddress(answer(ans)); err != nil {
+		return fmt.Errorf("%q is not an email address", answer(ans))
+	}
+	return nil
+}
+
+func (certManagerModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:   "issuerKind",
+			Prompt: &survey.Select{Message: "Issuer kind:", Options: []string{"ClusterIssuer", "Issuer"}},
+		},
+		{
+			Name:   "issuerType",
+			Prompt: &survey.Select{Message: "Issuer type:", Options: []string{issuerACME, issuerSelfSigned}},
+		},
+		{
+			Name:     "issuerName",
+			Prompt:   &survey.Input{Message: "Issuer name:", Default: "letsencrypt"},
+			Validate: ValidateDNSSubdomain,
+		},
+		{
+			Name:   "createIssuer",
+			Prompt: &survey.Confirm{Message: "Generate the issuer as well?", Help: "Leave off when the issuer already exists in the cluster."},
+		},
+		{
+			Name:     "email",
+			Prompt:   &survey.Input{Message: "ACME account email (for a generated ACME issuer):"},
+			Validate: Optional(validateEmail),
+		},
+		{
+			Name:     "dnsNames",
+			Prompt:   &survey.Input{Message: "DNS names, comma separated (optional):", Help: "Defaults to the hosts of the selected exposures."},
+			Validate: validateHostnames,
+		},
+		{
+			Name:     "secretName",
+			Prompt:   &survey.Input{Message: "TLS secret name (optional):", Help: "Defaults to <app>-tls."},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+	}
+}
+
+func (certManagerModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	kind, name := answers.String("issuerKind"), answers.String("issuerName")
+	acme := answers.String("issuerType") == issuerACME
+
+	secret := answers.String("secretName")
+	if secret == "" {
+		secret = l.App + "-tls"
+	}
+	dnsNames := splitList(answers.String("dnsNames"))
+	certificate := func(hosts []string) map[string]interface{} {
+		if len(dnsNames) > 0 {
+			hosts = dnsNames
+		}
+		return map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata":   map[string]interface{}{"name": l.App},
+			"spec": map[string]interface{}{
+				"secretName": secret,
+				"dnsNames":   hosts,
+				"issuerRef":  map[string]interface{}{"name": name, "kind": kind, "group": "cert-manager.io"},
+			},
+		}
+	}
+
+	var files []File
+	add := func(file string, obj map[string]interface{}) error {
+		content, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, file), Content: content})
+		l.Base.AddResource(file)
+		return nil
+	}
+
+	if answers.Bool("createIssuer") {
+		spec := map[string]interface{}{"selfSigned": map[string]interface{}{}}
+		if acme {
+			email := answers.String("email")
+			if email == "" {
+				return nil, fmt.Errorf("an ACME issuer needs an account email")
+			}
+			names := dnsNames
+			if len(names) == 0 {
+				for _, env := range append([]string{""}, l.Envs...) {
+					names = append(names, l.Routing.HostsFor(l.App, env, "")...)
+				}
+			}
+			for _, host := range names {
+				if strings.HasPrefix(host, "*.") {
+					return nil, fmt.Errorf("%s: wildcard certificates need a DNS-01 solver, use an existing issuer", host)
+				}
+			}
+			spec = map[string]interface{}{"acme": map[string]interface{}{
+				"server":              letsEncryptServer,
+				"email":               email,
+				"privateKeySecretRef": map[string]interface{}{"name": name + "-account-key"},
+				"solvers":             []interface{}{map[string]interface{}{"http01": http01Solver(l.Routing.Backend)}},
+			}}
+		}
+		err := add(strings.ToLower(kind)+"-"+name+".yaml", map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": name},
+			"spec":       spec,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cert, err := routeFiles(l, "certificate.yaml", "", certificate)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, cert...)
+	l.Routing.TLSSecret = secret
+	return files, nil
+}
+
+// http01Solver answers ACME HTTP-01 challenges through the routing backend.
+func http01Solver(backend string) map[string]interface{} {
+	switch backend {
+	case BackendGatewayAPI:
+		return map[string]interface{}{"gatewayHTTPRoute": map[string]interface{}{
+			"parentRefs": []interface{}{map[string]interface{}{"name": PublicGatewayAPIName, "namespace": GatewayNamespace}},
+		}}
+	case BackendIstio:
+		return map[string]interface{}{"ingress": map[string]interface{}{"class": "istio"}}
+	}
+	return map[string]interface{}{"ingress": map[string]interface{}{"ingressClassName": "nginx"}}
+}
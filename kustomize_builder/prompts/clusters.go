@@ -0,0 +1,170 @@
+package prompts
+
+import (
+	"path"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Cluster is a target cluster with overlays of some of the environment
+// overlays.
+type Cluster struct {
+	Name   string
+	Envs   []string
+	Region string
+	// Domain replaces the parent domain of the app's hosts.
+	Domain       string
+	StorageClass string
+	NodeSelector map[string]string
+}
+
+// withDomain moves host below domain, keeping its first label.
+func withDomain(host, domain string) string {
+	if domain == "" {
+		return host
+	}
+	label, _, _ := strings.Cut(host, ".")
+	return label + "." + domain
+}
+
+// ClusterOptions asks for the target clusters and generates an overlay of
+// each of their environments below clusters/<cluster>/<env>.
+func ClusterOptions(l *Layout) error {
+	clusters, err := keepEntries("Keep clusters:", l.Clusters, func(c Cluster) string { return c.Name })
+	if err != nil {
+		return err
+	}
+	names, err := askList("Target cluster", "Adds clusters/<name>/<env> overlays on top of the environment overlays.", ValidateDNSLabel)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		clusters = append(clusters, Cluster{Name: name})
+	}
+
+	overlays := map[string]*Kustomization{}
+	for i := range clusters {
+		c := &clusters[i]
+		if err := askCluster(l, c); err != nil {
+			return err
+		}
+		for _, env := range c.Envs {
+			dir := ClusterDir(c.Name, env)
+			k, ok := l.ClusterOverlays[dir]
+			if !ok {
+				k = NewKustomization()
+				k.AddResource("../../../" + OverlayDir(env))
+			}
+			files, err := clusterPatches(l, c, env, k)
+			if err != nil {
+				return err
+			}
+			l.AddFiles(dir, files...)
+			overlays[dir] = k
+		}
+	}
+	l.Clusters = clusters
+	l.ClusterOverlays = overlays
+	return nil
+}
+
+func askCluster(l *Layout, c *Cluster) error {
+	envs := c.Envs
+	if len(envs) == 0 {
+		envs = l.Envs
+	}
+	answers := struct {
+		Envs         []string
+		Region       string
+		Domain       string
+		StorageClass string
+	}{}
+	qs := []*survey.Question{
+		{
+			Name:     "envs",
+			Prompt:   &survey.MultiSelect{Message: "Environments on " + c.Name + ":", Options: l.Envs, Default: envs},
+			Validate: survey.Required,
+		},
+		{
+			Name:     "region",
+			Prompt:   &survey.Input{Message: "Region (optional):", Default: c.Region, Help: "Pins pods to nodes labelled topology.kubernetes.io/region."},
+			Validate: Optional(ValidateLabelValue),
+		},
+		{
+			Name:     "domain",
+			Prompt:   &survey.Input{Message: "Domain (optional):", Default: c.Domain, Help: "E.g. eu.example.com serves app.example.com as app.eu.example.com."},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+		{
+			Name:     "storageClass",
+			Prompt:   &survey.Input{Message: "StorageClass of PersistentVolumeClaims (optional):", Default: c.StorageClass},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+	}
+	if err := ask(qs, &answers); err != nil {
+		return err
+	}
+	c.Envs, c.Region, c.Domain, c.StorageClass = answers.Envs, answers.Region, answers.Domain, answers.StorageClass
+
+	selectors, err := askList("Node selector KEY=VALUE for "+c.Name, "", ValidateLabel)
+	if err != nil {
+		return err
+	}
+	nodeSelector := map[string]string{}
+	if len(selectors) == 0 {
+		for key, value := range c.NodeSelector {
+			nodeSelector[key] = value
+		}
+	}
+	for _, s := range selectors {
+		key, value, _ := strings.Cut(s, "=")
+		nodeSelector[key] = value
+	}
+	if c.Region != "" {
+		nodeSelector["topology.kubernetes.io/region"] = c.Region
+	}
+	c.NodeSelector = nodeSelector
+	return nil
+}
+
+// clusterPatches returns the patches of the cluster overlay k of env,
+// relative to its directory, and adds them to k.
+func clusterPatches(l *Layout, c *Cluster, env string, k *Kustomization) ([]File, error) {
+	var files []File
+	add := func(file string, obj interface{}, target *Selector) error {
+		content, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		p := path.Join("patches", file)
+		files = append(files, File{Path: p, Content: content})
+		k.AddPatch(Patch{Path: p, Target: target})
+		return nil
+	}
+
+	if c.Domain != "" {
+		for _, r := range l.Routing.routes {
+			var hosts []string
+			for _, host := range l.Routing.HostsFor(l.App, env, r.Exposure) {
+				hosts = append(hosts, withDomain(host, c.Domain))
+			}
+			if err := add(r.File, r.Manifest(hosts), nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if c.StorageClass != "" {
+		ops := []jsonPatchOp{{Op: "add", Path: "/spec/storageClassName", Value: c.StorageClass}}
+		if err := add("storage-class.yaml", ops, &Selector{Kind: "PersistentVolumeClaim"}); err != nil {
+			return nil, err
+		}
+	}
+	if len(c.NodeSelector) > 0 {
+		ops := []jsonPatchOp{{Op: "add", Path: "/spec/template/spec/nodeSelector", Value: c.NodeSelector}}
+		if err := add("node-selector.yaml", ops, &Selector{Kind: "Deployment|StatefulSet|DaemonSet"}); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,99 @@
+package prompts
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWithDomain(t *testing.T) {
+	for _, tc := range []struct {
+		host, domain, want string
+	}{
+		{"shop.example.com", "", "shop.example.com"},
+		{"shop.example.com", "eu.example.com", "shop.eu.example.com"},
+		{"shop-dev.internal.example.com", "eu.example.com", "shop-dev.eu.example.com"},
+		{"shop", "eu.example.com", "shop.eu.example.com"},
+	} {
+		if got := withDomain(tc.host, tc.domain); got != tc.want {
+			t.Errorf("withDomain(%q, %q) = %q, want %q", tc.host, tc.domain, got, tc.want)
+		}
+	}
+}
+
+func TestClusterOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// lines answer the environments, region, domain and StorageClass
+		// of the cluster eu, then its node selectors.
+		lines []string
+		envs  []string
+		files map[string]string
+	}{
+		{
+			name:  "no patches",
+			lines: []string{"", "", "", "", ""},
+			envs:  []string{"dev", "prod"},
+		},
+		{
+			name:  "region",
+			lines: []string{"prod", "eu-west-1", "", "", ""},
+			envs:  []string{"prod"},
+			files: map[string]string{
+				"clusters/eu/prod/patches/node-selector.yaml": "topology.kubernetes.io/region: eu-west-1",
+			},
+		},
+		{
+			name:  "storage class and node selector",
+			lines: []string{"dev", "", "", "fast-ssd", "pool=apps", ""},
+			envs:  []string{"dev"},
+			files: map[string]string{
+				"clusters/eu/dev/patches/storage-class.yaml": "value: fast-ssd",
+				"clusters/eu/dev/patches/node-selector.yaml": "pool: apps",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLayout("shop", []string{"dev", "prod"})
+			lines := append([]string{"eu", ""}, tc.lines...)
+			sc := NewScript(strings.NewReader(strings.Join(lines, "\n") + "\n"))
+			if err := sc.Run(func() error { return ClusterOptions(l) }); err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			if len(l.Clusters) != 1 || !reflect.DeepEqual(l.Clusters[0].Envs, tc.envs) {
+				t.Fatalf("clusters = %+v, want eu on %v", l.Clusters, tc.envs)
+			}
+
+			var dirs []string
+			for dir, k := range l.ClusterOverlays {
+				dirs = append(dirs, dir)
+				env := strings.TrimPrefix(dir, "clusters/eu/")
+				if want := []string{"../../../" + OverlayDir(env)}; !reflect.DeepEqual(k.Resources, want) {
+					t.Errorf("%s resources = %v, want %v", dir, k.Resources, want)
+				}
+			}
+			sort.Strings(dirs)
+			var wantDirs []string
+			for _, env := range tc.envs {
+				wantDirs = append(wantDirs, ClusterDir("eu", env))
+			}
+			if !reflect.DeepEqual(dirs, wantDirs) {
+				t.Errorf("overlays = %v, want %v", dirs, wantDirs)
+			}
+
+			got := map[string]string{}
+			for _, f := range l.Files {
+				got[f.Path] = string(f.Content)
+			}
+			if len(got) != len(tc.files) {
+				t.Errorf("files = %v, want %d", got, len(tc.files))
+			}
+			for p, want := range tc.files {
+				if !strings.Contains(got[p], want) {
+					t.Errorf("%s lacks %q:\n%s", p, want, got[p])
+				}
+			}
+		})
+	}
+}
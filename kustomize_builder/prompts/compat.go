@@ -0,0 +1,247 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// KustomizeVersion is a kustomize release line the kustomizations can be
+// rendered for, with the kubectl versions embedding it and the fields it
+// supports besides those all versions do.
+type KustomizeVersion struct {
+	Name    string
+	Kubectl string
+
+	// DirectoryResources is whether resources may list directories, which
+	// older versions list as bases.
+	DirectoryResources bool
+	// Patches is whether the patches field exists, which older versions
+	// split into patchesStrategicMerge and patchesJson6902.
+	Patches bool
+	// Labels is whether the labels field exists. Older versions only have
+	// commonLabels, which also sets selectors.
+	Labels       bool
+	Components   bool
+	Replacements bool
+	HelmCharts   bool
+	// Deprecates is whether the version deprecates the fields older ones
+	// have instead.
+	Deprecates bool
+}
+
+func (v KustomizeVersion) String() string {
+	return fmt.Sprintf("kustomize %s (kubectl %s)", v.Name, v.Kubectl)
+}
+
+// KustomizeVersions are the versions the kustomizations can target, latest
+// first.
+var KustomizeVersions = []KustomizeVersion{
+	{Name: "v5", Kubectl: "1.27+", DirectoryResources: true, Patches: true, Labels: true, Components: true, Replacements: true, HelmCharts: true, Deprecates: true},
+	{Name: "v4.1", Kubectl: "1.22-1.26", DirectoryResources: true, Patches: true, Labels: true, Components: true, Replacements: true, HelmCharts: true},
+	{Name: "v4.0", Kubectl: "1.21", DirectoryResources: true, Patches: true, Components: true},
+	{Name: "v2.0", Kubectl: "1.14-1.20"},
+}
+
+// FindKustomizeVersion returns the version named name, such as v4.1.
+func FindKustomizeVersion(name string) (KustomizeVersion, error) {
+	var names []string
+	for _, v := range KustomizeVersions {
+		if v.Name == name || "v"+name == v.Name {
+			return v, nil
+		}
+		names = append(names, v.Name)
+	}
+	return KustomizeVersion{}, fmt.Errorf("unknown kustomize version %q, known are %s", name, strings.Join(names, ", "))
+}
+
+// deprecatedFields are the fields kustomize v5 deprecates, with what replaces
+// them.
+var deprecatedFields = map[string]string{
+	"bases":                 "resources",
+	"commonLabels":          "labels",
+	"patchesStrategicMerge": "patches",
+	"patchesJson6902":       "patches",
+	"vars":                  "replacements",
+	"imageTags":             "images",
+}
+
+// compatible returns k of dir as the layout's kustomize version supports
+// it: newer fields are rewritten to the older ones with the same effect, or
+// reported when the version has none.
+func (l *Layout) compatible(dir string, k *Kustomization) (*Kustomization, error) {
+	if l.Kustomize == "" {
+		return k, nil
+	}
+	v, err := FindKustomizeVersion(l.Kustomize)
+	if err != nil {
+		return nil, err
+	}
+	k = k.Clone()
+	var unsupported []string
+	if !v.Components && (len(k.Components) > 0 || k.Kind == "Component") {
+		unsupported = append(unsupported, "components")
+	}
+	if !v.Replacements && len(k.Replacements) > 0 {
+		unsupported = append(unsupported, "replacements")
+	}
+	if !v.HelmCharts && len(k.HelmCharts) > 0 {
+		unsupported = append(unsupported, "helmCharts")
+	}
+	if !v.Labels {
+		for _, label := range k.Labels {
+			if !label.IncludeSelectors {
+				unsupported = append(unsupported, "labels left out of selectors")
+				break
+			}
+			if k.CommonLabels == nil {
+				k.CommonLabels = map[string]string{}
+			}
+			for key, value := range label.Pairs {
+				k.CommonLabels[key] = value
+			}
+		}
+		k.Labels = nil
+	}
+	if !v.Patches {
+		for _, p := range k.Patches {
+			ref, err := l.olderPatch(dir, p)
+			if err != nil {
+				unsupported = append(unsupported, err.Error())
+				continue
+			}
+			if ref.Target == nil {
+				k.PatchesStrategicMerge = append(k.PatchesStrategicMerge, ref.Path)
+			} else {
+				k.PatchesJSON6902 = append(k.PatchesJSON6902, ref)
+			}
+		}
+		k.Patches = nil
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("%s: %s does not support %s", dir, v, strings.Join(unsupported, ", "))
+	}
+
+	if !v.DirectoryResources {
+		var files []string
+		for _, r := range k.Resources {
+			switch path.Ext(r) {
+			case ".yaml", ".yml", ".json":
+				if !isRemote(r) {
+					files = append(files, r)
+					continue
+				}
+			}
+			k.Bases = append(k.Bases, r)
+		}
+		k.Resources = files
+	}
+	return k, nil
+}
+
+// olderPatch returns p of dir as an entry of patchesStrategicMerge, without
+// a target, or of patchesJson6902. Strategic merge patches select what they
+// patch by their own kind and name, JSON 6902 patches need a target naming
+// a single resource.
+func (l *Layout) olderPatch(dir string, p Patch) (JSON6902Patch, error) {
+	if p.Path == "" {
+		return JSON6902Patch{}, fmt.Errorf("inline patches")
+	}
+	var content []byte
+	found := false
+	for _, f := range l.Files {
+		if f.Path == path.Join(dir, p.Path) {
+			content, found = f.Content, true
+		}
+	}
+	if !found {
+		return JSON6902Patch{}, fmt.Errorf("patch %s, whose type is unknown", p.Path)
+	}
+	var n yaml.Node
+	if err := yaml.Unmarshal(content, &n); err != nil {
+		return JSON6902Patch{}, fmt.Errorf("patch %s: %v", p.Path, err)
+	}
+	jsonPatch := len(n.Content) > 0 && n.Content[0].Kind == yaml.SequenceNode
+	t := p.Target
+	switch {
+	case !jsonPatch && t == nil:
+		return JSON6902Patch{Path: p.Path}, nil
+	case !jsonPatch:
+		return JSON6902Patch{}, fmt.Errorf("strategic merge patch %s with a target", p.Path)
+	case t == nil || t.Version == "" || t.Name == "" || strings.ContainsAny(t.Kind+t.Name, "|*.^$") ||
+		t.LabelSelector != "" || t.AnnotationSelector != "":
+		return JSON6902Patch{}, fmt.Errorf("JSON 6902 patch %s without a target naming one resource by version, kind and name", p.Path)
+	}
+	return JSON6902Patch{
+		Target: &Selector{Group: t.Group, Version: t.Version, Kind: t.Kind, Name: t.Name, Namespace: t.Namespace},
+		Path:   p.Path,
+	}, nil
+}
+
+// deprecations returns the fields of the existing kustomizations the latest
+// kustomize deprecates, sorted by directory.
+func (l *Layout) deprecations() []string {
+	var found []string
+	for dir, data := range l.Existing {
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(data, &fields); err != nil {
+			continue
+		}
+		for field, replacement := range deprecatedFields {
+			if _, ok := fields[field]; ok {
+				found = append(found, fmt.Sprintf("%s: %s is deprecated, use %s", path.Join(dir, "kustomization.yaml"), field, replacement))
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
+
+// KustomizeVersionPrompt asks which kustomize version to render the
+// kustomizations of l for, offering those that can express them.
+func KustomizeVersionPrompt(l *Layout) (string, error) {
+	var options []string
+	var names []string
+	for _, v := range KustomizeVersions {
+		c := l.Clone()
+		c.Kustomize = v.Name
+		if _, err := c.Render(); err != nil {
+			note(err)
+			continue
+		}
+		options = append(options, v.String())
+		names = append(names, v.Name)
+	}
+	var i int
+	err := askOne(&survey.Select{Message: "Target kustomize version:", Options: options}, &i)
+	if err != nil {
+		return "", err
+	}
+	return names[i], nil
+}
+
+// CompatOptions renders the kustomizations of l for the kustomize version
+// named name, failing when they use fields it does not support, and warns
+// about the deprecated fields of existing kustomizations.
+func CompatOptions(l *Layout, name string) error {
+	v, err := FindKustomizeVersion(name)
+	if err != nil {
+		return err
+	}
+	c := l.Clone()
+	c.Kustomize = v.Name
+	if _, err := c.Render(); err != nil {
+		return err
+	}
+	l.Kustomize = v.Name
+	if v.Deprecates {
+		for _, d := range l.deprecations() {
+			note("Warning:", d+". kustomize edit fix rewrites it.")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// NewComponent returns an empty kustomize Component.
+func NewComponent() *Kustomization {
+	return &Kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1alpha1",
+		Kind:       "Component",
+	}
+}
+
+type componentTemplate struct {
+	Description string
+	Generate    func(app string) (*Kustomization, []File, error)
+}
+
+// componentLibrary are the components that can be added to a repository's
+// components/ library.
+var componentLibrary = map[string]componentTemplate{
+	"istio-ingress": {"VirtualService routing the public gateway to the app", istioIngressComponent},
+	"monitoring":    {"Prometheus scrape annotations on Deployments", monitoringComponent},
+	"pdb":           {"PodDisruptionBudget keeping one pod available", pdbComponent},
+}
+
+// LibraryComponents returns the components already present below dir.
+func LibraryComponents(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, "components"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, "components", e.Name(), "kustomization.yaml")); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// ComponentOptions asks which components each overlay uses. Components that
+// are not yet in the library below outDir are generated from the built-in
+// templates, or as empty components to be filled in by hand.
+func ComponentOptions(l *Layout, outDir string) error {
+	existing, err := LibraryComponents(outDir)
+	if err != nil {
+		return err
+	}
+	available := map[string]bool{}
+	for _, name := range existing {
+		available[name] = true
+	}
+
+	names := append([]string(nil), existing...)
+	labels := map[string]string{}
+	for name, t := range componentLibrary {
+		if !available[name] {
+			names = append(names, name)
+			labels[name] = name + " (new: " + t.Description + ")"
+		}
+	}
+	custom, err := askList("New empty component", "Creates components/<name> to be filled in by hand.", ValidateDNSLabel)
+	if err != nil {
+		return err
+	}
+	for _, name := range custom {
+		if available[name] {
+			continue
+		}
+		if labels[name] == "" {
+			names = append(names, name)
+		}
+		labels[name] = name + " (new: empty)"
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	options := make([]string, len(names))
+	for i, name := range names {
+		options[i] = name
+		if label, ok := labels[name]; ok {
+			options[i] = label
+		}
+	}
+
+	used := map[string]bool{}
+	for _, env := range l.Envs {
+		overlay := l.Overlays[env]
+		var current []string
+		var others []string
+		for _, c := range overlay.Components {
+			if i := indexOf(names, strings.TrimPrefix(c, "../../components/")); i >= 0 {
+				current = append(current, options[i])
+			} else {
+				others = append(others, c)
+			}
+		}
+		var selected []int
+		err := askOne(&survey.MultiSelect{
+			Message: "Components for " + env + ":",
+			Options: options,
+			Default: current,
+		}, &selected)
+		if err != nil {
+			return err
+		}
+		overlay.Components = others
+		for _, i := range selected {
+			name := names[i]
+			overlay.Components = append(overlay.Components, "../../"+ComponentDir(name))
+			used[name] = true
+		}
+	}
+
+	for name := range used {
+		if available[name] {
+			continue
+		}
+		component, files := NewComponent(), []File(nil)
+		if t, ok := componentLibrary[name]; ok && !containsString(custom, name) {
+			if component, files, err = t.Generate(l.App); err != nil {
+				return err
+			}
+		}
+		l.Components[name] = component
+		l.AddFiles(ComponentDir(name), files...)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	return indexOf(list, s) >= 0
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func appLabels(app string) map[string]interface{} {
+	return map[string]interface{}{"app.kubernetes.io/name": app}
+}
+
+func pdbComponent(app string) (*Kustomization, []File, error) {
+	content, err := marshalYAML(map[string]interface{}{
+		"apiVersion": "policy/v1",
+		"kind":       "PodDisruptionBudget",
+		"metadata":   map[string]interface{}{"name": app},
+		"spec": map[string]interface{}{
+			"minAvailable": 1,
+			"selector":     map[string]interface{}{"matchLabels": appLabels(app)},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	c := NewComponent()
+	c.AddResource("pdb.yaml")
+	return c, []File{{Path: "pdb.yaml", Content: content}}, nil
+}
+
+func monitoringComponent(app string) (*Kustomization, []File, error) {
+	content, err := marshalYAML(map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "not-used"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"prometheus.io/scrape": "true",
+						"prometheus.io/port":   "8080",
+						"prometheus.io/path":   "/metrics",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	c := NewComponent()
+	c.Patches = append(c.Patches, Patch{Path: "scrape-annotations.yaml", Target: &Selector{Kind: "Deployment"}})
+	return c, []File{{Path: "scrape-annotations.yaml", Content: content}}, nil
+}
+
+func istioIngressComponent(app string) (*Kustomization, []File, error) {
+	content, err := marshalYAML(virtualService(app, app, []string{defaultHost(app, "Public")}, PublicGateway, nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	c := NewComponent()
+	c.AddResource("virtualservice.yaml")
+	return c, []File{{Path: "virtualservice.yaml", Content: content}}, nil
+}
@@ -0,0 +1,113 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// ConfigMapArgs is a configMapGenerator entry.
+type ConfigMapArgs struct {
+	Name     string   `yaml:"name"`
+	Behavior string   `yaml:"behavior,omitempty"`
+	Literals []string `yaml:"literals,omitempty"`
+	Envs     []string `yaml:"envs,omitempty"`
+	Files    []string `yaml:"files,omitempty"`
+}
+
+// GeneratorOptions applies to all generators of a kustomization.
+type GeneratorOptions struct {
+	Labels                map[string]string `yaml:"labels,omitempty"`
+	Annotations           map[string]string `yaml:"annotations,omitempty"`
+	DisableNameSuffixHash bool              `yaml:"disableNameSuffixHash,omitempty"`
+}
+
+func validateLiteral(ans interface{}) error {
+	literal, _ := ans.(string)
+	key, _, ok := strings.Cut(literal, "=")
+	if !ok {
+		return fmt.Errorf("literal must be of the form KEY=VALUE")
+	}
+	return validateConfigMapKey(key)
+}
+
+func validateFileSource(ans interface{}) error {
+	source, _ := ans.(string)
+	key, path, ok := strings.Cut(source, "=")
+	if !ok {
+		return nil
+	}
+	if path == "" {
+		return fmt.Errorf("file source must be of the form [KEY=]PATH")
+	}
+	return validateConfigMapKey(key)
+}
+
+// ConfigMapOptions asks for configMapGenerator entries and generator options
+// and adds them to k.
+func ConfigMapOptions(k *Kustomization) error {
+	var err error
+	k.ConfigMapGenerator, err = keepEntries("Keep configMapGenerator entries:", k.ConfigMapGenerator,
+		func(a ConfigMapArgs) string { return a.Name })
+	if err != nil {
+		return err
+	}
+
+	more, err := askMore("Add a configMapGenerator entry?")
+	for ; err == nil && more; more, err = askMore("Add another configMapGenerator entry?") {
+		var args ConfigMapArgs
+		err = askOne(&survey.Input{Message: "ConfigMap name:"}, &args.Name,
+			survey.WithValidator(survey.Required), survey.WithValidator(ValidateDNSSubdomain))
+		if err != nil {
+			return err
+		}
+		if args.Literals, err = askList("Literal KEY=VALUE", "", validateLiteral); err != nil {
+			return err
+		}
+		if args.Envs, err = askList("Env file", "Path to a file of KEY=VALUE lines.", nil); err != nil {
+			return err
+		}
+		if args.Files, err = askList("File source [KEY=]PATH", "The key defaults to the file name.", validateFileSource); err != nil {
+			return err
+		}
+		k.ConfigMapGenerator = append(k.ConfigMapGenerator, args)
+	}
+	if err != nil {
+		return err
+	}
+	if len(k.ConfigMapGenerator) == 0 {
+		return nil
+	}
+	return generatorOptions(k)
+}
+
+// generatorOptions asks for generatorOptions unless k already has them.
+func generatorOptions(k *Kustomization) error {
+	if k.GeneratorOptions != nil {
+		return nil
+	}
+	opts := &GeneratorOptions{}
+	err := askOne(&survey.Confirm{
+		Message: "Disable the name suffix hash on generated resources?",
+		Help:    "Without the hash, pods are not rolled when the generated data changes.",
+	}, &opts.DisableNameSuffixHash)
+	if err != nil {
+		return err
+	}
+	labels, err := askList("Label KEY=VALUE for generated resources", "", ValidateLabel)
+	if err != nil {
+		return err
+	}
+	for _, l := range labels {
+		key, value, _ := strings.Cut(l, "=")
+		if opts.Labels == nil {
+			opts.Labels = map[string]string{}
+		}
+		opts.Labels[key] = value
+	}
+	if opts.DisableNameSuffixHash || len(opts.Labels) > 0 {
+		k.GeneratorOptions = opts
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadKustomization reads the kustomization.yaml in dir.
+func ReadKustomization(dir string) (*Kustomization, []byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	k := &Kustomization{}
+	if err := yaml.Unmarshal(data, k); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", filepath.Join(dir, "kustomization.yaml"), err)
+	}
+	return k, data, nil
+}
+
+// LoadLayout reads an existing kustomize tree with a base, overlays and
+// components below dir, so it can be edited with the wizard.
+func LoadLayout(dir string) (*Layout, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	l := NewLayout(filepath.Base(abs), nil)
+	l.Existing = map[string][]byte{}
+
+	base, data, err := ReadKustomization(filepath.Join(dir, BaseDir))
+	if err != nil {
+		return nil, err
+	}
+	l.Base = base
+	l.Existing[BaseDir] = data
+
+	envs, err := kustomizationDirs(filepath.Join(dir, "overlays"))
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range envs {
+		k, data, err := ReadKustomization(filepath.Join(dir, OverlayDir(env)))
+		if err != nil {
+			return nil, err
+		}
+		l.Envs = append(l.Envs, env)
+		l.Overlays[env] = k
+		l.Existing[OverlayDir(env)] = data
+	}
+
+	clusters, err := os.ReadDir(filepath.Join(dir, "clusters"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, e := range clusters {
+		if !e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		envs, err := kustomizationDirs(filepath.Join(dir, "clusters", name))
+		if err != nil {
+			return nil, err
+		}
+		if len(envs) == 0 {
+			continue
+		}
+		l.Clusters = append(l.Clusters, Cluster{Name: name, Envs: envs})
+		for _, env := range envs {
+			k, data, err := ReadKustomization(filepath.Join(dir, ClusterDir(name, env)))
+			if err != nil {
+				return nil, err
+			}
+			l.ClusterOverlays[ClusterDir(name, env)] = k
+			l.Existing[ClusterDir(name, env)] = data
+		}
+	}
+
+	components, err := kustomizationDirs(filepath.Join(dir, "components"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range components {
+		k, data, err := ReadKustomization(filepath.Join(dir, ComponentDir(name)))
+		if err != nil {
+			return nil, err
+		}
+		l.Components[name] = k
+		l.Existing[ComponentDir(name)] = data
+	}
+	return l, nil
+}
+
+// MergeOptions detects the overlays of an existing kustomize tree in dir and
+// asks which of them to modify. It returns the tree's layout, with the other
+// overlays and their cluster overlays kept as they are, or nil when dir has
+// no overlays yet.
+func MergeOptions(dir string) (*Layout, error) {
+	envs, err := kustomizationDirs(filepath.Join(dir, "overlays"))
+	if err != nil || len(envs) == 0 {
+		return nil, err
+	}
+	l, err := LoadLayout(dir)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(Out, "Found existing overlays in %s: %s\n", dir, strings.Join(envs, ", "))
+	var selected []string
+	err = askOne(&survey.MultiSelect{
+		Message: "Overlays to modify:",
+		Options: envs,
+		Default: envs,
+		Help:    "Generated resources and patches are merged into their kustomization.yaml, keeping comments and ordering. The other overlays are left as they are.",
+	}, &selected)
+	if err != nil {
+		return nil, err
+	}
+	l.Keep = map[string]bool{}
+	for _, env := range envs {
+		if containsString(selected, env) {
+			continue
+		}
+		l.Keep[OverlayDir(env)] = true
+		for _, c := range l.Clusters {
+			if containsString(c.Envs, env) {
+				l.Keep[ClusterDir(c.Name, env)] = true
+			}
+		}
+	}
+	return l, nil
+}
+
+// kustomizationDirs returns the subdirectories of dir holding a
+// kustomization.yaml.
+func kustomizationDirs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), "kustomization.yaml")); err == nil {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// kustomizationKeys are the top-level fields Kustomization manages.
+func kustomizationKeys() []string {
+	var keys []string
+	t := reflect.TypeOf(Kustomization{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("yaml")
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// mergeKustomization updates the original kustomization.yaml with k. Only
+// the sections that changed are replaced, so comments, ordering and fields
+// the builder does not know about are kept.
+func mergeKustomization(original []byte, k *Kustomization) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return marshalYAML(k)
+	}
+	root := doc.Content[0]
+
+	var updated yaml.Node
+	if err := updated.Encode(k); err != nil {
+		return nil, err
+	}
+
+	for _, key := range kustomizationKeys() {
+		newValue := mappingValue(&updated, key)
+		i := mappingIndex(root, key)
+		switch {
+		case newValue == nil && i >= 0:
+			root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		case newValue != nil && i < 0:
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, newValue)
+		case newValue != nil:
+			root.Content[i+1] = mergeNode(root.Content[i+1], newValue)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeNode returns updated, keeping the comments and ordering of original
+// where they still apply: list items and mapping keys found in both stay
+// where they were, new ones are appended and the others dropped.
+func mergeNode(original, updated *yaml.Node) *yaml.Node {
+	switch {
+	case original.Kind == yaml.SequenceNode && updated.Kind == yaml.SequenceNode:
+		merged := *original
+		merged.Content = nil
+		used := make([]bool, len(updated.Content))
+		for _, item := range original.Content {
+			for j, u := range updated.Content {
+				if !used[j] && sameValue(item, u) {
+					used[j] = true
+					merged.Content = append(merged.Content, item)
+					break
+				}
+			}
+		}
+		for j, u := range updated.Content {
+			if !used[j] {
+				merged.Content = append(merged.Content, u)
+			}
+		}
+		return &merged
+	case original.Kind == yaml.MappingNode && updated.Kind == yaml.MappingNode:
+		merged := *original
+		merged.Content = nil
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			if v := mappingValue(updated, original.Content[i].Value); v != nil {
+				merged.Content = append(merged.Content, original.Content[i], mergeNode(original.Content[i+1], v))
+			}
+		}
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			if mappingIndex(original, updated.Content[i].Value) < 0 {
+				merged.Content = append(merged.Content, updated.Content[i], updated.Content[i+1])
+			}
+		}
+		return &merged
+	case sameValue(original, updated):
+		return original
+	}
+	updated.HeadComment, updated.LineComment, updated.FootComment = original.HeadComment, original.LineComment, original.FootComment
+	return updated
+}
+
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(m, key); i >= 0 {
+		return m.Content[i+1]
+	}
+	return nil
+}
+
+func sameValue(a, b *yaml.Node) bool {
+	var va, vb interface{}
+	if a.Decode(&va) != nil || b.Decode(&vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,184 @@
+package prompts
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Shared Gateway API gateways HTTPRoutes attach to when the app does not get
+// its own, by exposure.
+const (
+	GatewayNamespace      = "gateway-system"
+	PublicGatewayAPIName  = "public"
+	PrivateGatewayAPIName = "private"
+)
+
+// gatewayAPIModule routes traffic to the app with Kubernetes Gateway API
+// HTTPRoutes, optionally with dedicated Gateways.
+type gatewayAPIModule struct{}
+
+func (gatewayAPIModule) Name() string { return "Gateway API" }
+
+func (gatewayAPIModule) Applies(l *Layout) bool { return usesBackend(l, BackendGatewayAPI) }
+
+func (gatewayAPIModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "createGateways",
+			Prompt: &survey.Confirm{
+				Message: "Create dedicated Gateways for the app?",
+				Help:    "Otherwise the routes attach to the shared " + GatewayNamespace + "/" + PublicGatewayAPIName + " and " + PrivateGatewayAPIName + " gateways, which a ReferenceGrant lets use the app's TLS secret.",
+			},
+		},
+		{
+			Name: "publicGatewayClass",
+			Prompt: &survey.Input{
+				Message: "GatewayClass of dedicated public Gateways:",
+				Default: "public",
+			},
+			Validate: ValidateDNSSubdomain,
+		},
+		{
+			Name: "privateGatewayClass",
+			Prompt: &survey.Input{
+				Message: "GatewayClass of dedicated private Gateways:",
+				Default: "private",
+				Help:    "A class provisioning an internal load balancer.",
+			},
+			Validate: ValidateDNSSubdomain,
+		},
+	}
+}
+
+// Asks asks for the classes of dedicated Gateways only, for the exposures
+// the app has.
+func (gatewayAPIModule) Asks(name string, answers Answers) bool {
+	switch name {
+	case "publicGatewayClass":
+		return answers.Bool("createGateways") && answers.Layout.Routing.Public
+	case "privateGatewayClass":
+		return answers.Bool("createGateways") && answers.Layout.Routing.Private
+	}
+	return true
+}
+
+func (gatewayAPIModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	create := answers.Bool("createGateways")
+	var files []File
+	for _, exposure := range l.Routing.Exposures() {
+		name := l.App + "-" + strings.ToLower(exposure)
+		parent := map[string]interface{}{"name": PublicGatewayAPIName, "namespace": GatewayNamespace}
+		class := answers.String("publicGatewayClass")
+		if exposure == "Private" {
+			parent["name"] = PrivateGatewayAPIName
+			class = answers.String("privateGatewayClass")
+		}
+		if create {
+			parent = map[string]interface{}{"name": name}
+			gw, err := routeFiles(l, "gateway-"+name+".yaml", exposure, func(hosts []string) map[string]interface{} {
+				return gateway(name, class, hosts, l.Routing.TLSSecret)
+			})
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, gw...)
+		}
+		route, err := routeFiles(l, "httproute-"+name+".yaml", exposure, func(hosts []string) map[string]interface{} {
+			return httpRoute(name, l.App, hosts, parent)
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, route...)
+	}
+	if !create && l.Routing.TLSSecret != "" {
+		grant, err := marshalYAML(referenceGrant(l.App+"-tls", l.Routing.TLSSecret))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, "referencegrant.yaml"), Content: grant})
+		l.Base.AddResource("referencegrant.yaml")
+		note("The shared gateways in " + GatewayNamespace + " terminate TLS once their HTTPS listeners reference the Secret " + l.Routing.TLSSecret + " in the app's namespace.")
+	}
+	return files, nil
+}
+
+// referenceGrant lets the shared gateways reference the Secret named secret
+// in the app's namespace as their certificate.
+func referenceGrant(name, secret string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind":       "ReferenceGrant",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"from": []interface{}{map[string]interface{}{
+				"group":     "gateway.networking.k8s.io",
+				"kind":      "Gateway",
+				"namespace": GatewayNamespace,
+			}},
+			"to": []interface{}{map[string]interface{}{"group": "", "kind": "Secret", "name": secret}},
+		},
+	}
+}
+
+// gateway returns a Gateway with a listener per host, and an HTTPS listener
+// per host terminating TLS with tlsSecret when it is set.
+func gateway(name, class string, hosts []string, tlsSecret string) map[string]interface{} {
+	var listeners []interface{}
+	for i, host := range hosts {
+		suffix := ""
+		if i > 0 {
+			suffix = "-" + strconv.Itoa(i)
+		}
+		listeners = append(listeners, map[string]interface{}{
+			"name":     "http" + suffix,
+			"protocol": "HTTP",
+			"port":     80,
+			"hostname": host,
+		})
+		if tlsSecret != "" {
+			listeners = append(listeners, map[string]interface{}{
+				"name":     "https" + suffix,
+				"protocol": "HTTPS",
+				"port":     443,
+				"hostname": host,
+				"tls": map[string]interface{}{
+					"mode":            "Terminate",
+					"certificateRefs": []interface{}{map[string]interface{}{"name": tlsSecret}},
+				},
+			})
+		}
+	}
+	return map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "Gateway",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"gatewayClassName": class,
+			"listeners":        listeners,
+		},
+	}
+}
+
+func httpRoute(name, app string, hosts []string, parent map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{parent},
+			"hostnames":  hosts,
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{"name": app, "port": 80},
+					},
+				},
+			},
+		},
+	}
+}
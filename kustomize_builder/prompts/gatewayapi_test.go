@@ -0,0 +1,37 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGatewayClassesOnlyAskedForDedicatedGateways(t *testing.T) {
+	for _, tc := range []struct {
+		create, exposure string
+		public, private  bool
+	}{
+		{"n", "Public,Private", false, false},
+		{"y", "Public", true, false},
+		{"y", "Public,Private", true, true},
+	} {
+		s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev"})}
+		sc := &Script{
+			Answers: map[string]string{
+				"Routing backend:": BackendGatewayAPI,
+				"Expose the app publicly, privately or both:": tc.exposure,
+				"Create dedicated Gateways for the app?":      tc.create,
+			},
+			Defaults: true,
+		}
+		if _, err := sc.Wizard(s, []Step{ModuleStep(routingModule{}), ModuleStep(gatewayAPIModule{})}); err != nil {
+			t.Fatalf("%s %s: %v", tc.create, tc.exposure, err)
+		}
+		transcript := sc.Transcript.String()
+		if got := strings.Contains(transcript, "GatewayClass of dedicated public Gateways:"); got != tc.public {
+			t.Errorf("%s %s: public class asked = %v, want %v", tc.create, tc.exposure, got, tc.public)
+		}
+		if got := strings.Contains(transcript, "GatewayClass of dedicated private Gateways:"); got != tc.private {
+			t.Errorf("%s %s: private class asked = %v, want %v", tc.create, tc.exposure, got, tc.private)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// git runs a git command in dir, with stdin as its input, and returns its
+// output. Failures include what git printed.
+func git(dir, stdin string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// GitOptions asks whether to commit the generated files to the git
+// repository outDir is in, and on which branch. An empty branch commits on
+// the current one.
+func GitOptions(l *Layout, outDir string) (commit bool, branch string, err error) {
+	if gitOutput(outDir, "rev-parse", "--show-toplevel") == "" {
+		return false, "", nil
+	}
+	err = askOne(&survey.Confirm{
+		Message: "Commit the generated files to git?",
+		Help:    "Lands the scaffolding as a reviewable change, with the answers in the commit message.",
+	}, &commit)
+	if err != nil || !commit {
+		return false, "", err
+	}
+	var target string
+	err = askOne(&survey.Select{
+		Message: "Commit on:",
+		Options: []string{branchNew, branchCurrent},
+	}, &target)
+	if err != nil || target == branchCurrent {
+		return commit, "", err
+	}
+	err = askOne(&survey.Input{
+		Message: "Branch to create:",
+		Default: "kustomize-builder/" + l.App,
+	}, &branch, survey.WithValidator(survey.Required), survey.WithValidator(validateBranch(outDir)))
+	return commit, branch, err
+}
+
+// Where GitOptions offers to commit.
+const (
+	branchNew     = "A new branch"
+	branchCurrent = "The current branch"
+)
+
+func validateBranch(dir string) survey.Validator {
+	return func(ans interface{}) error {
+		name := answer(ans)
+		if _, err := git(dir, "", "check-ref-format", "--branch", name); err != nil {
+			return fmt.Errorf("%q is not a valid branch name", name)
+		}
+		if gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/heads/"+name) != "" {
+			return fmt.Errorf("branch %s already exists", name)
+		}
+		return nil
+	}
+}
+
+// CommitMessage describes the generated change, with the answers summary as
+// its body.
+func CommitMessage(l *Layout) (string, error) {
+	summary, err := Summary(l)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Generate kustomize tree for %s (%s)\n\n%s\nGenerated-by: kustomize_builder\n",
+		l.App, strings.Join(l.Envs, ", "), summary), nil
+}
+
+// CommitFiles commits files, written to dir, with message, first creating
+// branch when it is not empty. The diff of the commit is written to w.
+// Other changes in the repository are left out of the commit.
+func CommitFiles(w io.Writer, dir, branch string, files []File, message string) error {
+	paths := []string{"--"}
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	if branch != "" {
+		if _, err := git(dir, "", "switch", "--create", branch); err != nil {
+			return err
+		}
+	}
+	if _, err := git(dir, "", append([]string{"add"}, paths...)...); err != nil {
+		return err
+	}
+	if _, err := git(dir, "", append([]string{"diff", "--cached", "--quiet"}, paths...)...); err == nil {
+		fmt.Fprintln(w, "No changes to commit")
+		return nil
+	}
+	if _, err := git(dir, message, append([]string{"commit", "--quiet", "--file", "-"}, paths...)...); err != nil {
+		return err
+	}
+	diff, err := git(dir, "", "show", "--stat", "--patch", "HEAD")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, diff)
+	return err
+}
@@ -0,0 +1,173 @@
+package prompts
+
+import (
+	"bytes"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// GitOps tools the overlays can be deployed with.
+const (
+	GitOpsNone   = "None"
+	GitOpsArgoCD = "Argo CD"
+	GitOpsFlux   = "Flux"
+)
+
+// GitOpsDir is the directory of the generated GitOps manifests.
+const GitOpsDir = "gitops"
+
+// GitSource is where the GitOps tool pulls the tree from.
+type GitSource struct {
+	URL      string
+	Revision string
+	// Path is the output directory relative to the repository root.
+	Path string
+}
+
+// gitOutput returns the trimmed output of a git command run in dir, or ""
+// when it fails, e.g. outside a repository.
+func gitOutput(dir string, args ...string) string {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// GitOpsOptions asks whether to deploy the overlays with Argo CD or Flux and
+// generates an Application, or a GitRepository and Kustomization, per
+// overlay below gitops/. The source defaults to the repository outDir is in.
+func GitOpsOptions(l *Layout, outDir string) error {
+	var tool string
+	err := askOne(&survey.Select{
+		Message: "Deploy the overlays with:",
+		Options: []string{GitOpsNone, GitOpsArgoCD, GitOpsFlux},
+	}, &tool)
+	if err != nil || tool == GitOpsNone {
+		return err
+	}
+
+	revision := gitOutput(outDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if revision == "" || revision == "HEAD" {
+		revision = "main"
+	}
+	answers := struct {
+		URL      string
+		Revision string
+		Path     string
+	}{}
+	qs := []*survey.Question{
+		{
+			Name:     "url",
+			Prompt:   &survey.Input{Message: "Git repository URL:", Default: gitOutput(outDir, "remote", "get-url", "origin")},
+			Validate: survey.Required,
+		},
+		{
+			Name:     "revision",
+			Prompt:   &survey.Input{Message: "Branch:", Default: revision},
+			Validate: survey.Required,
+		},
+		{
+			Name: "path",
+			Prompt: &survey.Input{
+				Message: "Path of the tree in the repository:",
+				Default: strings.TrimSuffix(gitOutput(outDir, "rev-parse", "--show-prefix"), "/"),
+				Help:    "Empty when the tree is at the repository root.",
+			},
+		},
+	}
+	if err := ask(qs, &answers); err != nil {
+		return err
+	}
+	source := GitSource{URL: answers.URL, Revision: answers.Revision, Path: strings.Trim(answers.Path, "/")}
+
+	type target struct{ dir, name, cluster string }
+	var targets []target
+	for _, env := range l.Envs {
+		targets = append(targets, target{OverlayDir(env), l.App + "-" + env, ""})
+	}
+	for _, c := range l.Clusters {
+		for _, env := range c.Envs {
+			targets = append(targets, target{ClusterDir(c.Name, env), l.App + "-" + c.Name + "-" + env, c.Name})
+		}
+	}
+	for _, t := range targets {
+		var content []byte
+		if tool == GitOpsArgoCD {
+			content, err = marshalYAML(argoApplication(t.name, source, t.dir, t.cluster))
+		} else {
+			content, err = fluxKustomization(t.name, source, t.dir)
+		}
+		if err != nil {
+			return err
+		}
+		file := t.name + ".yaml"
+		if t.cluster != "" {
+			file = path.Join(t.cluster, file)
+		}
+		l.AddFiles(GitOpsDir, File{Path: file, Content: content})
+	}
+	return nil
+}
+
+// argoApplication deploys dir to the named Argo CD cluster, or to the
+// cluster Argo CD runs in.
+func argoApplication(name string, source GitSource, dir, cluster string) map[string]interface{} {
+	destination := map[string]interface{}{"server": "https://kubernetes.default.svc"}
+	if cluster != "" {
+		destination = map[string]interface{}{"name": cluster}
+	}
+	return map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Application",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "argocd"},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        source.URL,
+				"targetRevision": source.Revision,
+				"path":           path.Join(source.Path, dir),
+			},
+			"destination": destination,
+			"syncPolicy": map[string]interface{}{
+				"automated": map[string]interface{}{"prune": true, "selfHeal": true},
+			},
+		},
+	}
+}
+
+// fluxKustomization returns a GitRepository and a Flux Kustomization
+// reconciling dir from it.
+func fluxKustomization(name string, source GitSource, dir string) ([]byte, error) {
+	repo, err := marshalYAML(map[string]interface{}{
+		"apiVersion": "source.toolkit.fluxcd.io/v1",
+		"kind":       "GitRepository",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "flux-system"},
+		"spec": map[string]interface{}{
+			"interval": "1m",
+			"url":      source.URL,
+			"ref":      map[string]interface{}{"branch": source.Revision},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ks, err := marshalYAML(map[string]interface{}{
+		"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+		"kind":       "Kustomization",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "flux-system"},
+		"spec": map[string]interface{}{
+			"interval":  "10m",
+			"path":      "./" + path.Join(source.Path, dir),
+			"prune":     true,
+			"sourceRef": map[string]interface{}{"kind": "GitRepository", "name": name},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join([][]byte{repo, ks}, []byte("---\n")), nil
+}
@@ -0,0 +1,87 @@
+package prompts
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGitOpsOptions(t *testing.T) {
+	for _, tc := range []struct {
+		tool string
+		want map[string][]string
+	}{
+		{tool: GitOpsNone},
+		{
+			tool: GitOpsArgoCD,
+			want: map[string][]string{
+				"gitops/shop-dev.yaml": {
+					"kind: Application",
+					"repoURL: https://git.example.com/shop.git",
+					"targetRevision: release",
+					"path: deploy/shop/overlays/dev",
+					"server: https://kubernetes.default.svc",
+				},
+				"gitops/eu-west/shop-eu-west-prod.yaml": {
+					"kind: Application",
+					"path: deploy/shop/clusters/eu-west/prod",
+					"name: eu-west",
+				},
+			},
+		},
+		{
+			tool: GitOpsFlux,
+			want: map[string][]string{
+				"gitops/shop-dev.yaml": {
+					"kind: GitRepository",
+					"url: https://git.example.com/shop.git",
+					"branch: release",
+					"kind: Kustomization",
+					"path: ./deploy/shop/overlays/dev",
+				},
+				"gitops/eu-west/shop-eu-west-prod.yaml": {
+					"kind: Kustomization",
+					"path: ./deploy/shop/clusters/eu-west/prod",
+				},
+			},
+		},
+	} {
+		t.Run(tc.tool, func(t *testing.T) {
+			l := NewLayout("shop", []string{"dev"})
+			l.Clusters = []Cluster{{Name: "eu-west", Envs: []string{"prod"}}}
+			sc := &Script{Answers: map[string]string{
+				"Deploy the overlays with:":           tc.tool,
+				"Git repository URL:":                 "https://git.example.com/shop.git",
+				"Branch:":                             "release",
+				"Path of the tree in the repository:": "/deploy/shop/",
+			}}
+			if err := sc.Run(func() error { return GitOpsOptions(l, t.TempDir()) }); err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			got := map[string]string{}
+			for _, f := range l.Files {
+				got[f.Path] = string(f.Content)
+			}
+			var paths, wantPaths []string
+			for p := range got {
+				paths = append(paths, p)
+			}
+			for p := range tc.want {
+				wantPaths = append(wantPaths, p)
+			}
+			sort.Strings(paths)
+			sort.Strings(wantPaths)
+			if !reflect.DeepEqual(paths, wantPaths) {
+				t.Fatalf("files = %v, want %v", paths, wantPaths)
+			}
+			for p, lines := range tc.want {
+				for _, line := range lines {
+					if !strings.Contains(got[p], line) {
+						t.Errorf("%s lacks %q:\n%s", p, line, got[p])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package prompts
+
+import (
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenRuns drive the module steps of the wizard for the app "shop" with
+// the environments dev and prod. Prompts they leave unanswered take their
+// default, which declines the modules not opted into.
+var goldenRuns = []struct {
+	name    string
+	answers map[string]string
+}{
+	{"deployment", map[string]string{
+		"Generate a workload for the app?": "y",
+		"Workload type:":                   KindDeployment,
+		"Container image:":                 "registry.example.com/shop:1.0.0",
+		"Environment variables KEY=VALUE, comma separated (optional):": "LOG_LEVEL=info",
+		"Health probes:": probeHTTP,
+	}},
+	{"statefulset", map[string]string{
+		"Generate a workload for the app?": "y",
+		"Workload type:":                   KindStatefulSet,
+		"Container image:":                 "registry.example.com/shop:1.0.0",
+		"Health probes:":                   probeTCP,
+		"Volume size:":                     "10Gi",
+		"Storage class (optional):":        "fast",
+	}},
+	{"cronjob", map[string]string{
+		"Generate a workload for the app?": "y",
+		"Workload type:":                   KindCronJob,
+		"Container image:":                 "registry.example.com/shop:1.0.0",
+		"Schedule (cron):":                 "*/15 * * * *",
+		"Concurrency policy:":              "Replace",
+	}},
+	{"job", map[string]string{
+		"Generate a workload for the app?": "y",
+		"Workload type:":                   KindJob,
+		"Container image:":                 "registry.example.com/shop:1.0.0",
+		"Completions:":                     "3",
+		"Parallelism:":                     "2",
+	}},
+	{"istio", map[string]string{
+		"Routing backend:": BackendIstio,
+		"Expose the app publicly, privately or both:":                                 "Public,Private",
+		"Public hostnames, comma separated (optional):":                               "shop.example.com",
+		"Split traffic between versions of the app?":                                  "y",
+		"Generate AuthorizationPolicies denying all but explicitly allowed requests?": "y",
+		"Allowed source namespaces, comma separated (optional):":                      "frontend",
+		"Set the Istio mTLS mode per environment?":                                    "y",
+		"Configure Istio sidecar injection?":                                          "y",
+	}},
+	{"gatewayapi", map[string]string{
+		"Routing backend:": BackendGatewayAPI,
+		"Expose the app publicly, privately or both:":       "Public",
+		"Terminate TLS with a cert-manager certificate?":    "y",
+		"Generate the issuer as well?":                      "y",
+		"ACME account email (for a generated ACME issuer):": "ops@example.com",
+	}},
+	{"ingress", map[string]string{
+		"Routing backend:": BackendIngress,
+		"Expose the app publicly, privately or both:":    "Public,Private",
+		"Private hostnames, comma separated (optional):": "shop.internal.example.com",
+		"Path:": "/shop",
+	}},
+	{"availability", map[string]string{
+		"Generate a PodDisruptionBudget and topology spread constraints?": "y",
+	}},
+	{"cronjob-availability", map[string]string{
+		"Generate a workload for the app?": "y",
+		"Workload type:":                   KindCronJob,
+		"Container image:":                 "registry.example.com/shop:1.0.0",
+		"Schedule (cron):":                 "0 3 * * *",
+		"Generate a PodDisruptionBudget and topology spread constraints?": "y",
+		"Run the app with a dedicated ServiceAccount?":                    "y",
+	}},
+	{"networkpolicy", map[string]string{
+		"Generate NetworkPolicies?":                                        "y",
+		"Other namespaces allowed to connect, comma separated (optional):": "monitoring",
+		"CIDRs allowed to connect, comma separated (optional):":            "10.0.0.0/8",
+	}},
+	{"monitoring", map[string]string{
+		"Generate a workload for the app?":        "y",
+		"Workload type:":                          KindDeployment,
+		"Container image:":                        "registry.example.com/shop:1.0.0",
+		"Does the app expose Prometheus metrics?": "y",
+		"Generate a Grafana dashboard ConfigMap?": "y",
+		"Scrape interval for prod:":               "15s",
+	}},
+	{"rbac", map[string]string{
+		"Run the app with a dedicated ServiceAccount?": "y",
+		"Other rules, separated by ; (optional):":      "deployments.apps:get,list",
+	}},
+}
+
+func TestGolden(t *testing.T) {
+	var steps []Step
+	for _, m := range Modules() {
+		steps = append(steps, ModuleStep(m))
+	}
+	for _, run := range goldenRuns {
+		t.Run(run.name, func(t *testing.T) {
+			s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev", "prod"})}
+			sc := &Script{Answers: run.answers, Defaults: true}
+			files, err := sc.Wizard(s, steps)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			if err := Golden(filepath.Join("testdata", "golden", run.name), files, *update); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestScriptMasksPasswords(t *testing.T) {
+	sc := &Script{Answers: map[string]string{"Value for TOKEN:": "hunter2"}}
+	var value string
+	err := sc.Run(func() error {
+		return askOne(&survey.Password{Message: "Value for TOKEN:"}, &value)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "hunter2" {
+		t.Errorf("answer = %q, want hunter2", value)
+	}
+	if strings.Contains(sc.Transcript.String(), "hunter2") {
+		t.Errorf("transcript shows the password:\n%s", sc.Transcript.String())
+	}
+}
@@ -0,0 +1,163 @@
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// httpClient fetches chart indexes and registry tags. The timeout keeps an
+// unreachable server from hanging the wizard.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// HelmChart is a helmCharts entry, inflated by kustomize build --enable-helm.
+type HelmChart struct {
+	Name        string `yaml:"name"`
+	Repo        string `yaml:"repo,omitempty"`
+	Version     string `yaml:"version,omitempty"`
+	ReleaseName string `yaml:"releaseName,omitempty"`
+	Namespace   string `yaml:"namespace,omitempty"`
+	ValuesFile  string `yaml:"valuesFile,omitempty"`
+	IncludeCRDs bool   `yaml:"includeCRDs,omitempty"`
+}
+
+// HelmOptions asks for helmCharts entries, checks that each chart version
+// exists and returns a starter values file per chart.
+func HelmOptions(k *Kustomization) ([]File, error) {
+	var files []File
+	var err error
+	k.HelmCharts, err = keepEntries("Keep Helm charts:", k.HelmCharts,
+		func(c HelmChart) string { return c.Name + " " + c.Version })
+	if err != nil {
+		return nil, err
+	}
+
+	more, err := askMore("Add a Helm chart?")
+	for ; err == nil && more; more, err = askMore("Add another Helm chart?") {
+		chart, err := askChart(HelmChart{Namespace: k.Namespace})
+		if err != nil {
+			return nil, err
+		}
+		err = askOne(&survey.Input{Message: "Release name:", Default: chart.Name}, &chart.ReleaseName,
+			survey.WithValidator(ValidateDNSLabel))
+		if err != nil {
+			return nil, err
+		}
+		err = askOne(&survey.Confirm{Message: "Include the chart's CRDs?"}, &chart.IncludeCRDs)
+		if err != nil {
+			return nil, err
+		}
+
+		chart.ValuesFile = "values-" + chart.ReleaseName + ".yaml"
+		files = append(files, File{Path: chart.ValuesFile, Content: starterValues(chart)})
+		k.HelmCharts = append(k.HelmCharts, chart)
+	}
+	return files, err
+}
+
+// askChart asks for the chart's repository, name and version until the
+// version is found, offering the previous answers again after a failed check.
+// Without a terminal to ask again, a failed check is an error.
+func askChart(chart HelmChart) (HelmChart, error) {
+	for {
+		qs := []*survey.Question{
+			{
+				Name:     "Repo",
+				Prompt:   &survey.Input{Message: "Chart repository URL:", Default: chart.Repo, Help: "An http(s) chart repository or an oci:// registry."},
+				Validate: survey.ComposeValidators(survey.Required, validateChartRepo),
+			},
+			{
+				Name:     "Name",
+				Prompt:   &survey.Input{Message: "Chart name:", Default: chart.Name},
+				Validate: survey.Required,
+			},
+			{
+				Name:     "Version",
+				Prompt:   &survey.Input{Message: "Chart version:", Default: chart.Version},
+				Validate: survey.Required,
+			},
+		}
+		if err := ask(qs, &chart); err != nil {
+			return chart, err
+		}
+		err := checkChartVersion(chart)
+		if err == nil || !Interactive() {
+			return chart, err
+		}
+		note("Error:", err)
+	}
+}
+
+func validateChartRepo(ans interface{}) error {
+	repo, _ := ans.(string)
+	for _, scheme := range []string{"https://", "http://", "oci://"} {
+		if strings.HasPrefix(repo, scheme) {
+			return nil
+		}
+	}
+	return fmt.Errorf("repository must be an http(s):// or oci:// URL")
+}
+
+// checkChartVersion verifies the chart version is published in the
+// repository's index, or with helm for OCI registries.
+func checkChartVersion(chart HelmChart) error {
+	if strings.HasPrefix(chart.Repo, "oci://") {
+		ref := strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name
+		if out, err := exec.Command("helm", "show", "chart", ref, "--version", chart.Version).CombinedOutput(); err != nil {
+			return fmt.Errorf("chart %s version %s: %s", ref, chart.Version, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+
+	resp, err := httpClient.Get(strings.TrimSuffix(chart.Repo, "/") + "/index.yaml")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching index of %s: %s", chart.Repo, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var index struct {
+		Entries map[string][]struct {
+			Version string `yaml:"version"`
+		} `yaml:"entries"`
+	}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("parsing index of %s: %w", chart.Repo, err)
+	}
+	versions, ok := index.Entries[chart.Name]
+	if !ok {
+		return fmt.Errorf("chart %s not found in %s", chart.Name, chart.Repo)
+	}
+	for _, v := range versions {
+		if v.Version == chart.Version {
+			return nil
+		}
+	}
+	return fmt.Errorf("chart %s has no version %s in %s", chart.Name, chart.Version, chart.Repo)
+}
+
+// starterValues returns the chart's default values when helm is available,
+// and an empty values file otherwise.
+func starterValues(chart HelmChart) []byte {
+	header := fmt.Sprintf("# Values for %s %s from %s.\n", chart.Name, chart.Version, chart.Repo)
+	args := []string{"show", "values", chart.Name, "--repo", chart.Repo, "--version", chart.Version}
+	if strings.HasPrefix(chart.Repo, "oci://") {
+		args = []string{"show", "values", strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name, "--version", chart.Version}
+	}
+	out, err := exec.Command("helm", args...).Output()
+	if err != nil {
+		return []byte(header + "{}\n")
+	}
+	return append([]byte(header), out...)
+}
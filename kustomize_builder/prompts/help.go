@@ -0,0 +1,985 @@
+package prompts
+
+import (
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// questionHelp explains a question: what its answer controls, an example of
+// the YAML it generates and where that is documented.
+type questionHelp struct {
+	// Text explains questions that do not set their own Help.
+	Text    string
+	Example string
+	Doc     string
+}
+
+const kustomizeDocs = "https://kubectl.docs.kubernetes.io/references/kustomize/kustomization/"
+
+// questionHelps holds the help of the questions by message. A * in a message
+// stands for the parts filled in when asking, such as the environment.
+var questionHelps = map[string]questionHelp{
+	// Wizard
+	"Continue:": {
+		Text: "Next goes on to the next step, Back undoes the previous step and asks it again, Redo asks this step again.",
+	},
+	"Step to change:": {
+		Text: "Asks the step again. The steps after it are undone and asked again as well.",
+	},
+	"Generate these files?": {
+		Text: "Confirm builds, validates and previews the files before writing them. Change a step goes back to it, Abort writes nothing.",
+	},
+	"Start from a preset:": {
+		Text: "Answers the common steps with a known setup, which later steps can still change.",
+	},
+	"Application name:": {
+		Example: "labels:\n  - pairs:\n      app.kubernetes.io/name: shop",
+		Doc:     "https://kubernetes.io/docs/concepts/overview/working-with-objects/common-labels/",
+	},
+	"Environments:": {
+		Text:    "Each environment becomes an overlay of the base under overlays/<env>.",
+		Example: "# overlays/prod/kustomization.yaml\nresources:\n  - ../../base",
+		Doc:     "https://kubectl.docs.kubernetes.io/guides/introduction/kustomize/",
+	},
+	"Additional environment (empty to finish)": {
+		Text:    "Adds an overlay for an environment not listed.",
+		Example: "# overlays/qa/kustomization.yaml\nresources:\n  - ../../base",
+		Doc:     "https://kubectl.docs.kubernetes.io/guides/introduction/kustomize/",
+	},
+	"Overlays to modify:": {
+		Text: "Generated resources and patches are merged into their kustomization.yaml. The other overlays are left as they are.",
+	},
+
+	// Base
+	"Target namespace:": {
+		Example: "namespace: shop",
+		Doc:     kustomizeDocs + "namespace/",
+	},
+	"Generate a Namespace manifest for *?": {
+		Text:    "Adds the Namespace itself to the base, for clusters where it is not created otherwise.",
+		Example: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop",
+		Doc:     "https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/",
+	},
+	"Name prefix (optional):": {
+		Text:    "Prepended to the names of all resources, and to the references to them.",
+		Example: "namePrefix: team-",
+		Doc:     kustomizeDocs + "nameprefix/",
+	},
+	"Name suffix (optional):": {
+		Text:    "Appended to the names of all resources, and to the references to them.",
+		Example: "nameSuffix: -v2",
+		Doc:     kustomizeDocs + "namesuffix/",
+	},
+	"Common labels:": {
+		Text:    "Recommended labels added to all resources.",
+		Example: "labels:\n  - pairs:\n      app.kubernetes.io/name: shop\n      app.kubernetes.io/part-of: shop",
+		Doc:     kustomizeDocs + "labels/",
+	},
+	"Owning team (optional):": {
+		Example: "labels:\n  - pairs:\n      team: payments",
+		Doc:     kustomizeDocs + "labels/",
+	},
+	"Other common label KEY=VALUE (empty to finish)": {
+		Text:    "Adds a label to all resources.",
+		Example: "labels:\n  - pairs:\n      cost-center: \"4711\"",
+		Doc:     kustomizeDocs + "labels/",
+	},
+	"Add the labels to pod templates?": {
+		Example: "labels:\n  - pairs:\n      team: payments\n    includeTemplates: true",
+		Doc:     kustomizeDocs + "labels/",
+	},
+	"Add the labels to selectors?": {
+		Example: "labels:\n  - pairs:\n      team: payments\n    includeSelectors: true",
+		Doc:     kustomizeDocs + "labels/",
+	},
+	"Keep common annotations:": {
+		Text:    "Deselected annotations are removed from the kustomization.",
+		Example: "commonAnnotations:\n  owner: payments@example.com",
+		Doc:     kustomizeDocs + "commonannotations/",
+	},
+	"Common annotation KEY=VALUE (empty to finish)": {
+		Text:    "Adds an annotation to all resources.",
+		Example: "commonAnnotations:\n  owner: payments@example.com",
+		Doc:     kustomizeDocs + "commonannotations/",
+	},
+	"Directory with existing manifests (optional):": {
+		Example: "resources:\n  - deployment.yaml\n  - service.yaml",
+		Doc:     kustomizeDocs + "resource/",
+	},
+	"Select resources to include:": {
+		Text:    "The selected files are listed as resources of the base.",
+		Example: "resources:\n  - deployment.yaml\n  - service.yaml",
+		Doc:     kustomizeDocs + "resource/",
+	},
+	"Keep remote bases:": {
+		Text:    "Deselected remote bases are removed from the resources.",
+		Example: "resources:\n  - https://github.com/org/repo//deploy/base?ref=v1.2.0",
+		Doc:     kustomizeDocs + "resource/",
+	},
+	"Remote base URL (empty to finish):": {
+		Example: "resources:\n  - https://github.com/org/repo//deploy/base?ref=v1.2.0",
+		Doc:     kustomizeDocs + "resource/",
+	},
+	"Pin to tag or commit:": {
+		Example: "resources:\n  - https://github.com/org/repo//deploy/base?ref=v1.2.0",
+		Doc:     kustomizeDocs + "resource/",
+	},
+
+	// Generators
+	"Keep configMapGenerator entries:": {
+		Text:    "Deselected ConfigMaps are no longer generated.",
+		Example: "configMapGenerator:\n  - name: shop-config\n    literals:\n      - LOG_LEVEL=info",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"Add a configMapGenerator entry?": {
+		Text:    "Generates a ConfigMap from literals and files, named with a hash of its data so pods roll when it changes.",
+		Example: "configMapGenerator:\n  - name: shop-config\n    literals:\n      - LOG_LEVEL=info",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"Add another configMapGenerator entry?": {
+		Text:    "Generates another ConfigMap.",
+		Example: "configMapGenerator:\n  - name: shop-features\n    files:\n      - features.json",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"ConfigMap name:": {
+		Text:    "The name resources refer to the ConfigMap by. kustomize updates the references to the hashed name.",
+		Example: "configMapGenerator:\n  - name: shop-config",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"Literal KEY=VALUE (empty to finish)": {
+		Text:    "A key of the ConfigMap with its value.",
+		Example: "configMapGenerator:\n  - name: shop-config\n    literals:\n      - LOG_LEVEL=info",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"Env file (empty to finish)": {
+		Text:    "Path to a file of KEY=VALUE lines, each becoming a key.",
+		Example: "envs:\n  - config.env",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"File source [KEY=]PATH (empty to finish)": {
+		Text:    "A file becoming one key. The key defaults to the file name.",
+		Example: "files:\n  - nginx.conf\n  - app.json=config/app.json",
+		Doc:     kustomizeDocs + "configmapgenerator/",
+	},
+	"Disable the name suffix hash on generated resources?": {
+		Example: "generatorOptions:\n  disableNameSuffixHash: true",
+		Doc:     kustomizeDocs + "generatoroptions/",
+	},
+	"Label KEY=VALUE for generated resources (empty to finish)": {
+		Text:    "Labels added to the generated ConfigMaps and Secrets only.",
+		Example: "generatorOptions:\n  labels:\n    config: shop",
+		Doc:     kustomizeDocs + "generatoroptions/",
+	},
+	"Keep secretGenerator entries:": {
+		Text:    "Deselected Secrets are no longer generated.",
+		Example: "secretGenerator:\n  - name: shop-db\n    envs:\n      - db.env",
+		Doc:     kustomizeDocs + "secretgenerator/",
+	},
+	"Add a secret?": {
+		Text:    "Adds a Secret, generated from files or stored encrypted in the repository.",
+		Example: "secretGenerator:\n  - name: shop-db\n    envs:\n      - db.env",
+		Doc:     kustomizeDocs + "secretgenerator/",
+	},
+	"Add another secret?": {
+		Text:    "Adds another Secret.",
+		Example: "secretGenerator:\n  - name: shop-tls\n    type: kubernetes.io/tls",
+		Doc:     kustomizeDocs + "secretgenerator/",
+	},
+	"Secret name:": {
+		Text:    "The name resources refer to the Secret by.",
+		Example: "secretGenerator:\n  - name: shop-db",
+		Doc:     kustomizeDocs + "secretgenerator/",
+	},
+	"Secret type:": {
+		Text:    "Types other than Opaque require certain keys, such as tls.crt and tls.key.",
+		Example: "secretGenerator:\n  - name: shop-tls\n    type: kubernetes.io/tls",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/secret/#secret-types",
+	},
+	"How should * be stored?": {
+		Text:    "A generator reads the values from files kept out of git. Sealed Secrets and SOPS encrypt them so they can be committed.",
+		Example: "apiVersion: bitnami.com/v1alpha1\nkind: SealedSecret\nmetadata:\n  name: shop-db\nspec:\n  encryptedData:\n    password: AgBy3i4OJSWK...",
+		Doc:     "https://github.com/bitnami-labs/sealed-secrets",
+	},
+	"Sealing certificate (optional):": {
+		Example: "kind: SealedSecret\nspec:\n  encryptedData:\n    password: AgBy3i4OJSWK...",
+		Doc:     "https://github.com/bitnami-labs/sealed-secrets",
+	},
+	"age recipient (optional):": {
+		Example: "kind: Secret\nstringData:\n  password: ENC[AES256_GCM,data:...]\nsops:\n  age:\n    - recipient: age1...",
+		Doc:     "https://github.com/getsops/sops",
+	},
+	"Secret key (empty to finish)": {
+		Text:    "A key of the Secret. Its value is asked next and not echoed.",
+		Example: "stringData:\n  password: ...",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/secret/",
+	},
+	"Value for *:": {
+		Text: "The value to set, as YAML: strings, numbers, lists and mappings are accepted.",
+	},
+
+	// Helm
+	"Keep Helm charts:": {
+		Text:    "Deselected charts are removed from the kustomization.",
+		Example: "helmCharts:\n  - name: redis\n    repo: https://charts.bitnami.com/bitnami\n    version: 18.1.0",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Add a Helm chart?": {
+		Text:    "Inflates a chart into the base. kustomize build then needs --enable-helm.",
+		Example: "helmCharts:\n  - name: redis\n    repo: https://charts.bitnami.com/bitnami\n    version: 18.1.0\n    releaseName: redis",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Add another Helm chart?": {
+		Text:    "Inflates another chart into the base.",
+		Example: "helmCharts:\n  - name: postgresql\n    repo: oci://registry-1.docker.io/bitnamicharts\n    version: 13.2.0",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Chart repository URL:": {
+		Example: "repo: https://charts.bitnami.com/bitnami",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Chart name:": {
+		Text:    "The chart's name in the repository.",
+		Example: "name: redis",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Chart version:": {
+		Text:    "The chart version to pin, so builds are repeatable.",
+		Example: "version: 18.1.0",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Release name:": {
+		Text:    "The release name the chart's templates see, usually part of the resource names.",
+		Example: "releaseName: redis",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+	"Include the chart's CRDs?": {
+		Text:    "Adds the CustomResourceDefinitions in the chart's crds/ directory to the output.",
+		Example: "includeCRDs: true",
+		Doc:     kustomizeDocs + "helmcharts/",
+	},
+
+	// Patches
+	"Keep patches:": {
+		Text:    "Deselected patches are removed from the kustomization. Their files are left in place.",
+		Example: "patches:\n  - path: patches/deployment-shop.yaml",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Add a patch?": {
+		Text:    "Changes fields of the resources, by strategic merge or JSON patch.",
+		Example: "patches:\n  - path: patches/deployment-shop.yaml",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Add another patch?": {
+		Text:    "Adds another patch.",
+		Example: "patches:\n  - path: patches/service-shop.yaml",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Patch type:": {
+		Text:    "A strategic merge patch is a partial resource merged into it. A JSON patch is a list of operations on paths.",
+		Example: "- op: replace\n  path: /spec/replicas\n  value: 3",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Patch target:": {
+		Text:    "The resource the patch applies to.",
+		Example: "patches:\n  - path: patches/deployment-shop.yaml\n    target:\n      kind: Deployment\n      name: shop",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Target group (empty for core):": {
+		Text:    "The API group of the target, e.g. apps for Deployments.",
+		Example: "target:\n  group: apps",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Target version:": {
+		Text:    "The API version of the target.",
+		Example: "target:\n  version: v1",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Target kind:": {
+		Text:    "The kind of the target.",
+		Example: "target:\n  kind: Deployment",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Target name:": {
+		Text:    "The name of the target in the base.",
+		Example: "target:\n  name: shop",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Field path (empty to finish)": {
+		Text:    "A dotted path to the field to set, e.g. spec.replicas.",
+		Example: "spec:\n  replicas: 3",
+		Doc:     kustomizeDocs + "patches/",
+	},
+	"Operation:": {
+		Text:    "add inserts a value, replace changes an existing one and remove deletes it.",
+		Example: "- op: add\n  path: /metadata/annotations/owner\n  value: payments",
+		Doc:     "https://datatracker.ietf.org/doc/html/rfc6902",
+	},
+	"Path:": {
+		Text:    "The path to match or change.",
+		Example: "- op: replace\n  path: /spec/replicas",
+		Doc:     "https://datatracker.ietf.org/doc/html/rfc6902",
+	},
+	"Value:": {
+		Text:    "The value of the operation, as YAML.",
+		Example: "- op: replace\n  path: /spec/replicas\n  value: 3",
+		Doc:     "https://datatracker.ietf.org/doc/html/rfc6902",
+	},
+
+	// Replacements
+	"Keep replacements:": {
+		Text:    "Deselected replacements are removed from the kustomization.",
+		Example: "replacements:\n  - source:\n      kind: ConfigMap\n      name: shop-config\n      fieldPath: data.DB_HOST",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Add a replacement?": {
+		Text:    "Copies the value of a field of one resource into fields of others, e.g. a ConfigMap value into an env var. Replaces kustomize vars.",
+		Example: "replacements:\n  - source:\n      kind: ConfigMap\n      name: shop-config\n      fieldPath: data.DB_HOST\n    targets:\n      - select:\n          kind: Deployment\n          name: shop\n        fieldPaths:\n          - spec.template.spec.containers.[name=shop].env.[name=DB_HOST].value",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Add another replacement?": {
+		Text:    "Copies another field.",
+		Example: "replacements:\n  - source:\n      kind: Service\n      name: shop\n      fieldPath: metadata.name",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Replacement source:": {
+		Text:    "The resource the value is copied from. Generated ConfigMaps are listed with the keys of their literals and env files.",
+		Example: "source:\n  kind: ConfigMap\n  name: shop-config",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Source field:": {
+		Text:    "The field whose value is copied.",
+		Example: "source:\n  fieldPath: data.DB_HOST",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Replacement targets:": {
+		Text:    "The resources the value is copied into.",
+		Example: "targets:\n  - select:\n      kind: Deployment\n      name: shop",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Fields of * to replace:": {
+		Text:    "The existing fields of the target that get the value.",
+		Example: "fieldPaths:\n  - spec.template.spec.containers.[name=shop].env.[name=DB_HOST].value",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Other field of * (empty to finish)": {
+		Example: "fieldPaths:\n  - spec.template.spec.containers.[name=shop].args.0",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Delimiter to replace part of the values (optional):": {
+		Example: "options:\n  delimiter: \":\"\n  index: 0",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+	"Index of the part to replace:": {
+		Text:    "Counts from 0, the part before the first delimiter.",
+		Example: "options:\n  delimiter: \":\"\n  index: 0",
+		Doc:     kustomizeDocs + "replacements/",
+	},
+
+	// Components, images and sizing
+	"New empty component (empty to finish)": {
+		Example: "apiVersion: kustomize.config.k8s.io/v1alpha1\nkind: Component",
+		Doc:     kustomizeDocs + "components/",
+	},
+	"Components for *:": {
+		Text:    "The components the overlay includes.",
+		Example: "components:\n  - ../../components/debug",
+		Doc:     kustomizeDocs + "components/",
+	},
+	"Images to override in *:": {
+		Text:    "The images whose name, tag or digest the overlay changes.",
+		Example: "images:\n  - name: shop\n    newTag: 1.4.2",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"Other image to override in * (empty to finish)": {
+		Text:    "The image name as used in the manifests, without tag.",
+		Example: "images:\n  - name: nginx\n    newTag: 1.25.3",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"New name for * (optional):": {
+		Example: "images:\n  - name: shop\n    newName: registry.example.com/shop",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"Look up tags of *?": {
+		Text:    "Lists the tags in the registry to choose from.",
+		Example: "images:\n  - name: shop\n    newTag: 1.4.2",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"Tag:": {
+		Text:    "The tag the overlay deploys.",
+		Example: "images:\n  - name: shop\n    newTag: 1.4.2",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"New tag or digest:": {
+		Text:    "A tag such as 1.4.2, or a digest such as sha256:... which pins the exact image.",
+		Example: "images:\n  - name: shop\n    digest: sha256:24a0c4b4a4c0eb97a1aabb8e29f18e917d05abfe1b7a7c07857230879ce7d3d3",
+		Doc:     kustomizeDocs + "images/",
+	},
+	"Set replicas and resources per environment?": {
+		Text:    "Patches each overlay with its own replica count and container requests and limits.",
+		Example: "replicas:\n  - name: shop\n    count: 3",
+		Doc:     kustomizeDocs + "replicas/",
+	},
+	"Workload to size:": {
+		Text:    "The Deployment, StatefulSet, CronJob or Job the resources, and the replicas of Deployments and StatefulSets, apply to.",
+		Example: "replicas:\n  - name: shop\n    count: 3",
+		Doc:     kustomizeDocs + "replicas/",
+	},
+	"Deployment name:": {
+		Text:    "The Deployment the replicas and resources apply to.",
+		Example: "replicas:\n  - name: shop\n    count: 3",
+		Doc:     kustomizeDocs + "replicas/",
+	},
+	"Container name:": {
+		Text:    "The container of the workload the requests and limits are set on.",
+		Example: "containers:\n  - name: shop\n    resources:\n      requests:\n        cpu: 100m",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+	},
+	"* replicas:": {
+		Text:    "The number of pods in this environment.",
+		Example: "replicas:\n  - name: shop\n    count: 3",
+		Doc:     kustomizeDocs + "replicas/",
+	},
+	"* CPU request:": {
+		Text:    "The CPU the scheduler reserves for the container, in cores or millicores such as 100m.",
+		Example: "resources:\n  requests:\n    cpu: 100m",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+	},
+	"* CPU limit (optional):": {
+		Text:    "The CPU the container is throttled at.",
+		Example: "resources:\n  limits:\n    cpu: 500m",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+	},
+	"* memory request:": {
+		Text:    "The memory the scheduler reserves for the container, such as 128Mi.",
+		Example: "resources:\n  requests:\n    memory: 128Mi",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+	},
+	"* memory limit (optional):": {
+		Text:    "The memory the container is killed at when it uses more.",
+		Example: "resources:\n  limits:\n    memory: 512Mi",
+		Doc:     "https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+	},
+
+	// Clusters
+	"Keep clusters:": {
+		Text:    "Deselected clusters have their overlays removed from the layout.",
+		Example: "# clusters/eu-1/prod/kustomization.yaml\nresources:\n  - ../../../overlays/prod",
+		Doc:     "https://kubectl.docs.kubernetes.io/guides/introduction/kustomize/",
+	},
+	"Target cluster (empty to finish)": {
+		Example: "# clusters/eu-1/prod/kustomization.yaml\nresources:\n  - ../../../overlays/prod",
+		Doc:     "https://kubectl.docs.kubernetes.io/guides/introduction/kustomize/",
+	},
+	"Environments on *:": {
+		Text:    "The environments deployed to the cluster, each getting a cluster overlay.",
+		Example: "# clusters/eu-1/prod/kustomization.yaml\nresources:\n  - ../../../overlays/prod",
+	},
+	"Region (optional):": {
+		Example: "nodeSelector:\n  topology.kubernetes.io/region: eu-west-1",
+		Doc:     "https://kubernetes.io/docs/reference/labels-annotations-taints/#topologykubernetesioregion",
+	},
+	"Domain (optional):": {
+		Example: "hosts:\n  - shop.eu.example.com",
+	},
+	"StorageClass of PersistentVolumeClaims (optional):": {
+		Text:    "Sets the class of all claims in the cluster's overlays.",
+		Example: "kind: PersistentVolumeClaim\nspec:\n  storageClassName: fast-ssd",
+		Doc:     "https://kubernetes.io/docs/concepts/storage/storage-classes/",
+	},
+	"Node selector KEY=VALUE for * (empty to finish)": {
+		Text:    "Schedules the workloads only on nodes with the label.",
+		Example: "nodeSelector:\n  node.kubernetes.io/instance-type: m5.large",
+		Doc:     "https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/",
+	},
+
+	// Modules
+	"Generate a workload for the app?": {
+		Text: "Bootstraps an app without manifests with a Deployment, StatefulSet, CronJob or Job.",
+		Doc:  "https://kubernetes.io/docs/concepts/workloads/",
+	},
+	"Workload type:": {
+		Text:    "Deployments and StatefulSets get a Service listening on port 80, StatefulSets also a volume per pod. CronJobs and Jobs run to completion.",
+		Example: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: shop",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/",
+	},
+	"Container image:": {
+		Example: "containers:\n  - name: shop\n    image: registry.example.com/shop:1.0.0",
+		Doc:     "https://kubernetes.io/docs/concepts/containers/images/",
+	},
+	"Container port:": {
+		Text:    "The port the app listens on, named http for the probes and the Service.",
+		Example: "ports:\n  - name: http\n    containerPort: 8080",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/service/",
+	},
+	"Health probes:": {
+		Text:    "Readiness probes keep traffic away from pods that are not ready, liveness probes restart pods that hang.",
+		Example: "readinessProbe:\n  httpGet:\n    path: /healthz\n    port: http",
+		Doc:     "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/",
+	},
+	"HTTP probe path:": {
+		Text:    "The path answering 2xx or 3xx while the app is healthy.",
+		Example: "httpGet:\n  path: /healthz\n  port: http",
+		Doc:     "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/",
+	},
+	"Environment variables KEY=VALUE, comma separated (optional):": {
+		Text:    "Set on the container. Use a ConfigMap or Secret for values that differ per environment.",
+		Example: "env:\n  - name: LOG_LEVEL\n    value: info",
+		Doc:     "https://kubernetes.io/docs/tasks/inject-data-application/define-environment-variable-container/",
+	},
+	"Volume mount path:": {
+		Text:    "Where the volume claimed for each pod of the StatefulSet is mounted.",
+		Example: "volumeMounts:\n  - name: data\n    mountPath: /data",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#volume-claim-templates",
+	},
+	"Volume size:": {
+		Example: "volumeClaimTemplates:\n  - metadata:\n      name: data\n    spec:\n      resources:\n        requests:\n          storage: 1Gi",
+		Doc:     "https://kubernetes.io/docs/concepts/storage/persistent-volumes/#persistentvolumeclaims",
+	},
+	"Storage class (optional):": {
+		Text:    "Leave empty for the cluster's default storage class.",
+		Example: "spec:\n  storageClassName: fast-ssd",
+		Doc:     "https://kubernetes.io/docs/concepts/storage/storage-classes/",
+	},
+	"Schedule (cron):": {
+		Text:    "Minute, hour, day of month, month and day of week, in the time zone of the controller manager. Macros such as @daily work too.",
+		Example: "spec:\n  schedule: \"*/15 9-17 * * mon-fri\"",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#schedule-syntax",
+	},
+	"Concurrency policy:": {
+		Example: "spec:\n  concurrencyPolicy: Forbid",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#concurrency-policy",
+	},
+	"Retries before the job fails:": {
+		Text:    "Failed pods are restarted with an exponential back-off until this many retries.",
+		Example: "spec:\n  backoffLimit: 3",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/job/#pod-backoff-failure-policy",
+	},
+	"Completions:": {
+		Text:    "How many pods must succeed for the Job to complete.",
+		Example: "spec:\n  completions: 1",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/job/#parallel-jobs",
+	},
+	"Parallelism:": {
+		Text:    "How many pods run at once.",
+		Example: "spec:\n  parallelism: 1",
+		Doc:     "https://kubernetes.io/docs/concepts/workloads/controllers/job/#parallel-jobs",
+	},
+	"Generate a PodDisruptionBudget and topology spread constraints?": {
+		Text:    "Keeps the app available during node drains and spreads its pods across failure domains.",
+		Example: "apiVersion: policy/v1\nkind: PodDisruptionBudget\nspec:\n  minAvailable: 1",
+		Doc:     "https://kubernetes.io/docs/tasks/run-application/configure-pdb/",
+	},
+	"Disruption budget:": {
+		Example: "spec:\n  minAvailable: 1",
+		Doc:     "https://kubernetes.io/docs/tasks/run-application/configure-pdb/",
+	},
+	"Pods, as a count or percentage:": {
+		Text:    "A number of pods, or a percentage of the replicas such as 50%.",
+		Example: "spec:\n  maxUnavailable: 25%",
+		Doc:     "https://kubernetes.io/docs/tasks/run-application/configure-pdb/",
+	},
+	"Spread pods across:": {
+		Text:    "The failure domains pods are spread across, by topology key.",
+		Example: "topologySpreadConstraints:\n  - topologyKey: topology.kubernetes.io/zone\n    maxSkew: 1",
+		Doc:     "https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+	},
+	"Maximum pod count difference between domains:": {
+		Text:    "How uneven the spread may get.",
+		Example: "topologySpreadConstraints:\n  - maxSkew: 1",
+		Doc:     "https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+	},
+	"Keep pods pending rather than violate the spread?": {
+		Example: "topologySpreadConstraints:\n  - whenUnsatisfiable: DoNotSchedule",
+		Doc:     "https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+	},
+	"Split traffic between versions of the app?": {
+		Text:    "Routes a share of the requests to each version, e.g. for canary releases.",
+		Example: "kind: VirtualService\nspec:\n  http:\n    - route:\n        - destination:\n            subset: canary\n          weight: 10",
+		Doc:     "https://istio.io/latest/docs/concepts/traffic-management/",
+	},
+	"Subsets and weights:": {
+		Example: "route:\n  - destination:\n      subset: stable\n    weight: 90\n  - destination:\n      subset: canary\n    weight: 10",
+		Doc:     "https://istio.io/latest/docs/reference/config/networking/virtual-service/",
+	},
+	"Pod label holding the subset name:": {
+		Text:    "Pods of each version carry this label with the subset name as value.",
+		Example: "kind: DestinationRule\nspec:\n  subsets:\n    - name: canary\n      labels:\n        version: canary",
+		Doc:     "https://istio.io/latest/docs/reference/config/networking/destination-rule/",
+	},
+	"Routing backend:": {
+		Text:    "What exposes the app: Istio Gateways and VirtualServices, Gateway API HTTPRoutes or Ingresses.",
+		Example: "apiVersion: gateway.networking.k8s.io/v1\nkind: HTTPRoute\nspec:\n  hostnames:\n    - shop.example.com",
+		Doc:     "https://gateway-api.sigs.k8s.io/",
+	},
+	"Expose the app publicly, privately or both:": {
+		Example: "parentRefs:\n  - name: public\n    namespace: gateways",
+	},
+	"Public hostnames, comma separated (optional):": {
+		Example: "hostnames:\n  - shop.example.com",
+	},
+	"Private hostnames, comma separated (optional):": {
+		Example: "hostnames:\n  - shop.internal.example.com",
+	},
+	"Terminate TLS with a cert-manager certificate?": {
+		Text:    "Generates a Certificate for the hosts and serves them over HTTPS.",
+		Example: "apiVersion: cert-manager.io/v1\nkind: Certificate\nspec:\n  secretName: shop-tls\n  dnsNames:\n    - shop.example.com",
+		Doc:     "https://cert-manager.io/docs/usage/certificate/",
+	},
+	"* hostnames for * (optional):": {
+		Text: "Overrides the base hostnames in this overlay.",
+	},
+	"Create dedicated Gateways for the app?": {
+		Text:    "Otherwise the routes attach to the shared gateways.",
+		Example: "apiVersion: gateway.networking.k8s.io/v1\nkind: Gateway\nspec:\n  gatewayClassName: public",
+		Doc:     "https://gateway-api.sigs.k8s.io/api-types/gateway/",
+	},
+	"GatewayClass of dedicated public Gateways:": {
+		Text:    "A class provisioning an internet-facing load balancer.",
+		Example: "kind: Gateway\nspec:\n  gatewayClassName: public",
+		Doc:     "https://gateway-api.sigs.k8s.io/api-types/gatewayclass/",
+	},
+	"GatewayClass of dedicated private Gateways:": {
+		Example: "kind: Gateway\nspec:\n  gatewayClassName: private",
+		Doc:     "https://gateway-api.sigs.k8s.io/api-types/gatewayclass/",
+	},
+	"ingressClassName for public traffic:": {
+		Text:    "The ingress controller serving internet-facing hosts.",
+		Example: "kind: Ingress\nspec:\n  ingressClassName: nginx",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/ingress/#ingress-class",
+	},
+	"ingressClassName for private traffic:": {
+		Text:    "The ingress controller serving internal hosts.",
+		Example: "kind: Ingress\nspec:\n  ingressClassName: nginx-internal",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/ingress/#ingress-class",
+	},
+	"TLS secret name (optional):": {
+		Text:    "Secret of type kubernetes.io/tls holding the certificate for the hosts.",
+		Example: "tls:\n  - hosts:\n      - shop.example.com\n    secretName: shop-tls",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/ingress/#tls",
+	},
+	"Issuer kind:": {
+		Text:    "A ClusterIssuer serves all namespaces, an Issuer only its own.",
+		Example: "issuerRef:\n  kind: ClusterIssuer\n  name: letsencrypt",
+		Doc:     "https://cert-manager.io/docs/configuration/",
+	},
+	"Issuer type:": {
+		Text:    "ACME requests certificates from e.g. Let's Encrypt, self-signed suits tests.",
+		Example: "kind: ClusterIssuer\nspec:\n  acme:\n    server: https://acme-v02.api.letsencrypt.org/directory",
+		Doc:     "https://cert-manager.io/docs/configuration/acme/",
+	},
+	"Issuer name:": {
+		Text:    "The issuer the Certificates refer to.",
+		Example: "issuerRef:\n  name: letsencrypt",
+		Doc:     "https://cert-manager.io/docs/configuration/",
+	},
+	"Generate the issuer as well?": {
+		Example: "apiVersion: cert-manager.io/v1\nkind: ClusterIssuer\nmetadata:\n  name: letsencrypt",
+		Doc:     "https://cert-manager.io/docs/configuration/",
+	},
+	"ACME account email (for a generated ACME issuer):": {
+		Text:    "The ACME server sends expiry notices to this address.",
+		Example: "spec:\n  acme:\n    email: ops@example.com",
+		Doc:     "https://cert-manager.io/docs/configuration/acme/",
+	},
+	"DNS names, comma separated (optional):": {
+		Example: "spec:\n  dnsNames:\n    - shop.example.com",
+		Doc:     "https://cert-manager.io/docs/usage/certificate/",
+	},
+	"Generate AuthorizationPolicies denying all but explicitly allowed requests?": {
+		Text:    "Denies requests to the app unless a policy allows them.",
+		Example: "apiVersion: security.istio.io/v1\nkind: AuthorizationPolicy\nspec:\n  action: ALLOW\n  rules:\n    - from:\n        - source:\n            namespaces: [frontend]",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/authorization-policy/",
+	},
+	"Allow requests through the ingress gateways?": {
+		Text:    "Allows requests coming in through the Istio ingress gateways.",
+		Example: "- from:\n    - source:\n        namespaces: [istio-system]",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/authorization-policy/",
+	},
+	"Allowed principals, comma separated (optional):": {
+		Example: "- from:\n    - source:\n        principals: [cluster.local/ns/shop/sa/frontend]",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/authorization-policy/",
+	},
+	"Allowed source namespaces, comma separated (optional):": {
+		Text:    "Allows requests from all workloads in these namespaces.",
+		Example: "- from:\n    - source:\n        namespaces: [frontend]",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/authorization-policy/",
+	},
+	"Paths they may request, comma separated (optional):": {
+		Example: "to:\n  - operation:\n      paths: [/api/*]",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/authorization-policy/",
+	},
+	"Set the Istio mTLS mode per environment?": {
+		Text:    "Generates PeerAuthentications requiring or permitting mutual TLS.",
+		Example: "apiVersion: security.istio.io/v1\nkind: PeerAuthentication\nspec:\n  mtls:\n    mode: STRICT",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/peer_authentication/",
+	},
+	"Apply the mode to:": {
+		Example: "kind: PeerAuthentication\nspec:\n  selector:\n    matchLabels:\n      app.kubernetes.io/name: shop",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/peer_authentication/",
+	},
+	"mTLS mode for *:": {
+		Text:    "STRICT accepts mutual TLS only, PERMISSIVE plain text as well, e.g. while migrating.",
+		Example: "spec:\n  mtls:\n    mode: STRICT",
+		Doc:     "https://istio.io/latest/docs/reference/config/security/peer_authentication/",
+	},
+	"Configure Istio sidecar injection?": {
+		Text:    "Labels the namespace or the pods to get, or not get, an Istio sidecar.",
+		Example: "metadata:\n  labels:\n    istio-injection: enabled",
+		Doc:     "https://istio.io/latest/docs/setup/additional-setup/sidecar-injection/",
+	},
+	"Inject sidecars?": {
+		Text:    "Enables or disables injection for the app.",
+		Example: "metadata:\n  labels:\n    sidecar.istio.io/inject: \"true\"",
+		Doc:     "https://istio.io/latest/docs/setup/additional-setup/sidecar-injection/",
+	},
+	"Label the:": {
+		Example: "kind: Namespace\nmetadata:\n  labels:\n    istio-injection: enabled",
+		Doc:     "https://istio.io/latest/docs/setup/additional-setup/sidecar-injection/",
+	},
+	"Istio revision for * (optional):": {
+		Example: "metadata:\n  labels:\n    istio.io/rev: stable",
+		Doc:     "https://istio.io/latest/docs/setup/upgrade/canary/",
+	},
+	"Generate NetworkPolicies?": {
+		Text:    "Denies traffic to and from the app's pods except what is allowed next.",
+		Example: "apiVersion: networking.k8s.io/v1\nkind: NetworkPolicy\nspec:\n  policyTypes: [Ingress, Egress]",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Container port to allow traffic to:": {
+		Text:    "The port other pods connect to.",
+		Example: "ingress:\n  - ports:\n      - port: 8080",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Allow traffic from pods in the same namespace?": {
+		Text:    "Allows connections from all pods of the namespace.",
+		Example: "ingress:\n  - from:\n      - podSelector: {}",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Other namespaces allowed to connect, comma separated (optional):": {
+		Text:    "Allows connections from all pods in these namespaces.",
+		Example: "- namespaceSelector:\n    matchLabels:\n      kubernetes.io/metadata.name: frontend",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"CIDRs allowed to connect, comma separated (optional):": {
+		Text:    "Allows connections from these address ranges, e.g. a load balancer subnet.",
+		Example: "- ipBlock:\n    cidr: 10.0.0.0/16",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Allowed egress:": {
+		Example: "egress:\n  - to:\n      - namespaceSelector: {}\n    ports:\n      - port: 53\n        protocol: UDP",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Other CIDRs the app connects to, comma separated (optional):": {
+		Text:    "Allows connections to these address ranges, e.g. a database subnet.",
+		Example: "egress:\n  - to:\n      - ipBlock:\n          cidr: 10.1.0.0/24",
+		Doc:     "https://kubernetes.io/docs/concepts/services-networking/network-policies/",
+	},
+	"Does the app expose Prometheus metrics?": {
+		Text:    "Generates a Prometheus Operator monitor scraping them, and optionally a Grafana dashboard.",
+		Example: "apiVersion: monitoring.coreos.com/v1\nkind: ServiceMonitor\nmetadata:\n  name: shop",
+		Doc:     "https://prometheus-operator.dev/docs/developer/getting-started/",
+	},
+	"Scrape with:": {
+		Example: "kind: PodMonitor\nspec:\n  podMetricsEndpoints:\n    - port: http",
+		Doc:     "https://prometheus-operator.dev/docs/developer/getting-started/",
+	},
+	"Metrics port name:": {
+		Text:    "The name of the Service port, or of the container port for PodMonitors.",
+		Example: "endpoints:\n  - port: http",
+		Doc:     "https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.Endpoint",
+	},
+	"Metrics path:": {
+		Example: "endpoints:\n  - path: /metrics",
+		Doc:     "https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.Endpoint",
+	},
+	"Scrape interval:": {
+		Text:    "How often Prometheus scrapes the app. Overlays can set their own.",
+		Example: "endpoints:\n  - interval: 30s",
+		Doc:     "https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.Endpoint",
+	},
+	"Scrape interval for *:": {
+		Text:    "Intervals other than the base's are patched into the overlay.",
+		Example: "patches:\n  - path: patches/scrape-interval.yaml\n    target:\n      kind: ServiceMonitor",
+		Doc:     "https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.Endpoint",
+	},
+	"Labels Prometheus selects monitors by, KEY=VALUE comma separated (optional):": {
+		Example: "metadata:\n  labels:\n    release: prometheus",
+		Doc:     "https://prometheus-operator.dev/docs/api-reference/api/#monitoring.coreos.com/v1.PrometheusSpec",
+	},
+	"Generate a Grafana dashboard ConfigMap?": {
+		Text:    "A dashboard of the app's CPU and memory, labeled grafana_dashboard for the Grafana sidecar to load.",
+		Example: "kind: ConfigMap\nmetadata:\n  name: shop-dashboard\n  labels:\n    grafana_dashboard: \"1\"",
+		Doc:     "https://github.com/grafana/helm-charts/tree/main/charts/grafana#sidecar-for-dashboards",
+	},
+	"Run the app with a dedicated ServiceAccount?": {
+		Text:    "Generates a ServiceAccount with only the permissions the app needs.",
+		Example: "apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: shop",
+		Doc:     "https://kubernetes.io/docs/reference/access-authn-authz/rbac/",
+	},
+	"ServiceAccount name (optional):": {
+		Example: "spec:\n  serviceAccountName: shop",
+		Doc:     "https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/",
+	},
+	"API permissions:": {
+		Text:    "Common sets of permissions granted to the ServiceAccount.",
+		Example: "kind: Role\nrules:\n  - apiGroups: [\"\"]\n    resources: [configmaps]\n    verbs: [get, list, watch]",
+		Doc:     "https://kubernetes.io/docs/reference/access-authn-authz/rbac/",
+	},
+	"Other rules, separated by ; (optional):": {
+		Example: "rules:\n  - apiGroups: [apps]\n    resources: [deployments]\n    verbs: [get, list]",
+		Doc:     "https://kubernetes.io/docs/reference/access-authn-authz/rbac/",
+	},
+	"Grant the permissions cluster-wide?": {
+		Example: "kind: ClusterRoleBinding\nroleRef:\n  kind: ClusterRole\n  name: shop",
+		Doc:     "https://kubernetes.io/docs/reference/access-authn-authz/rbac/",
+	},
+	"Deploy the overlays with:": {
+		Text:    "Generates an Argo CD Application or Flux Kustomization per overlay.",
+		Example: "apiVersion: argoproj.io/v1alpha1\nkind: Application\nspec:\n  source:\n    path: overlays/prod",
+		Doc:     "https://argo-cd.readthedocs.io/en/stable/operator-manual/declarative-setup/",
+	},
+	"Git repository URL:": {
+		Text:    "The repository the GitOps tool pulls the tree from.",
+		Example: "source:\n  repoURL: https://github.com/org/shop.git",
+	},
+	"Branch:": {
+		Text:    "The branch the GitOps tool follows.",
+		Example: "source:\n  targetRevision: main",
+	},
+	"Path of the tree in the repository:": {
+		Example: "source:\n  path: deploy/overlays/prod",
+	},
+
+	// Checks, writing and applying
+	"Target kustomize version:": {
+		Text:    "Older versions get the older forms of newer fields, such as patchesStrategicMerge for patches. Versions that cannot express the kustomizations are not offered.",
+		Example: "patchesStrategicMerge:\n  - patches/deployment-shop.yaml",
+		Doc:     "https://kubectl.docs.kubernetes.io/installation/kubectl/",
+	},
+	"Validate manifests against Kubernetes version:": {
+		Text: "kubeconform checks the build output against the API schemas of this version.",
+		Doc:  "https://github.com/yannh/kubeconform",
+	},
+	"Write the files anyway?": {
+		Text: "Writes the files despite the validation errors listed.",
+	},
+	"Generate fix patches for:": {
+		Example: "containers:\n  - name: shop\n    readinessProbe:\n      tcpSocket:\n        port: http",
+		Doc:     "https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/",
+	},
+	"Preview the kustomize build output?": {
+		Text: "Shows what kustomize build renders for each overlay.",
+	},
+	"Write the files?": {
+		Text: "Writes the files to the output directory, replacing existing ones.",
+	},
+	"Commit the generated files to git?": {},
+	"Commit on:": {
+		Text: "A new branch holds the commit ready for a pull request; the current branch gets it directly.",
+	},
+	"Branch to create:": {
+		Text: "Created from the current branch, which is left as it is.",
+	},
+	"Apply the manifests to a cluster?": {
+		Text: "Applies one overlay with kubectl after writing the files.",
+		Doc:  "https://kubernetes.io/docs/reference/using-api/server-side-apply/",
+	},
+	"Overlay to apply:": {
+		Text: "The environment whose build output is applied.",
+	},
+	"Kube context:": {
+		Text: "The cluster and user from the kubeconfig to apply with.",
+		Doc:  "https://kubernetes.io/docs/concepts/configuration/organize-cluster-access-kubeconfig/",
+	},
+	"Namespace:": {},
+	"Server-side apply *?": {
+		Text: "Applies the build output with kubectl apply --server-side.",
+		Doc:  "https://kubernetes.io/docs/reference/using-api/server-side-apply/",
+	},
+}
+
+// matchMessage reports whether message matches pattern, whose * stand for
+// any text.
+func matchMessage(pattern, message string) bool {
+	_, ok := wildcards(pattern, message)
+	return ok
+}
+
+// wildcards returns the parts of message the *s of pattern stand for,
+// reporting whether it matches.
+func wildcards(pattern, message string) ([]string, bool) {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return nil, pattern == message
+	}
+	if !strings.HasPrefix(message, parts[0]) {
+		return nil, false
+	}
+	var matched []string
+	rest := message[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return nil, false
+		}
+		matched = append(matched, rest[:i])
+		rest = rest[i+len(part):]
+	}
+	last := parts[len(parts)-1]
+	if !strings.HasSuffix(rest, last) {
+		return nil, false
+	}
+	return append(matched, rest[:len(rest)-len(last)]), true
+}
+
+// lookupHelp returns the help of the question with message.
+func lookupHelp(message string) (questionHelp, bool) {
+	if h, ok := questionHelps[message]; ok {
+		return h, true
+	}
+	for pattern, h := range questionHelps {
+		if strings.Contains(pattern, "*") && matchMessage(pattern, message) {
+			return h, true
+		}
+	}
+	return questionHelp{}, false
+}
+
+// describe sets the help of p, shown when answering ?, to what its answer
+// controls with an example of the generated YAML and a documentation link.
+// Help set on p itself explains it more specifically than the registered
+// text and is kept.
+func describe(p survey.Prompt) {
+	var message string
+	var help *string
+	switch p := p.(type) {
+	case *survey.Input:
+		message, help = p.Message, &p.Help
+	case *survey.Password:
+		message, help = p.Message, &p.Help
+	case *survey.Confirm:
+		message, help = p.Message, &p.Help
+	case *survey.Select:
+		message, help = p.Message, &p.Help
+	case *survey.MultiSelect:
+		message, help = p.Message, &p.Help
+	default:
+		return
+	}
+	h, ok := lookupHelp(message)
+	if !ok {
+		return
+	}
+	text := T(*help)
+	if text == "" {
+		text = T(h.Text)
+	}
+	if h.Example != "" {
+		text += "\n\n" + T("Example:") + "\n  " + strings.ReplaceAll(h.Example, "\n", "\n  ")
+	}
+	if h.Doc != "" {
+		text += "\n" + T("Docs:") + " " + h.Doc
+	}
+	*help = strings.TrimSpace(text)
+}
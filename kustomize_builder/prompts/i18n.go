@@ -0,0 +1,255 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"gopkg.in/yaml.v3"
+)
+
+// LocaleEnv selects the locale of the prompts, before the usual LC_ALL,
+// LC_MESSAGES and LANG.
+const LocaleEnv = "KUSTOMIZE_BUILDER_LANG"
+
+// translations holds the catalog of the selected locale: prompt messages,
+// help texts and options by their English text. A * in a text stands for
+// the parts filled in when asking, such as the environment, as in the help
+// of the questions.
+var translations map[string]string
+
+// patterns are the texts of translations with a *, the most specific first.
+var patterns []string
+
+// catalogs are the bundled translations by locale.
+var catalogs = map[string]map[string]string{
+	"de": catalogDE,
+}
+
+// LocaleDir returns the directory translation catalogs are read from, as
+// YAML files named after their locale, such as fr.yaml, mapping the English
+// texts to their translation. They add to and override the bundled ones.
+func LocaleDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kustomize_builder", "locales"), nil
+}
+
+// LocaleFromEnv returns the locale LocaleEnv or the POSIX locale variables
+// select, empty for English.
+func LocaleFromEnv() string {
+	for _, name := range []string{LocaleEnv, "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return normalizeLocale(v)
+		}
+	}
+	return ""
+}
+
+// normalizeLocale strips the encoding and modifier of POSIX locales, so
+// de_DE.UTF-8 becomes de_DE. The C locale is English.
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	locale = strings.Replace(locale, "-", "_", 1)
+	if locale == "C" || locale == "POSIX" || locale == "en" || strings.HasPrefix(locale, "en_") {
+		return ""
+	}
+	return locale
+}
+
+// SetLocale shows the prompts in locale, such as de or pt_BR, falling back
+// to the catalog of its language and then to English for texts without a
+// translation. Locales without any catalog are an error.
+func SetLocale(locale string) error {
+	locale = normalizeLocale(locale)
+	translations, patterns = nil, nil
+	if locale == "" {
+		return nil
+	}
+	lang, _, _ := strings.Cut(locale, "_")
+	candidates := []string{lang, locale}
+	if lang == locale {
+		candidates = candidates[:1]
+	}
+
+	merged := map[string]string{}
+	found := false
+	dir, dirErr := LocaleDir()
+	for _, name := range candidates {
+		for english, text := range catalogs[name] {
+			merged[english] = text
+			found = true
+		}
+		if dirErr != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name+".yaml"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		var user map[string]string
+		if err := yaml.Unmarshal(data, &user); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Join(dir, name+".yaml"), err)
+		}
+		for english, text := range user {
+			merged[english] = text
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no translations for locale %q, bundled are %s", locale, strings.Join(bundledLocales(), ", "))
+	}
+	translations = merged
+	for english := range merged {
+		if strings.Contains(english, "*") {
+			patterns = append(patterns, english)
+		}
+	}
+	fixed := func(pattern string) int { return len(pattern) - strings.Count(pattern, "*") }
+	sort.Slice(patterns, func(i, j int) bool {
+		if fixed(patterns[i]) != fixed(patterns[j]) {
+			return fixed(patterns[i]) > fixed(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	return nil
+}
+
+func bundledLocales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// T returns the translation of s in the selected locale, or s when it has
+// none. Texts with parts filled in take the translation of the pattern they
+// match, its *s replaced by the parts in order.
+func T(s string) string {
+	if t, ok := translations[s]; ok && t != "" {
+		return t
+	}
+	for _, pattern := range patterns {
+		parts, ok := wildcards(pattern, s)
+		segments := strings.Split(translations[pattern], "*")
+		if !ok || len(segments) != len(parts)+1 {
+			continue
+		}
+		t := segments[0]
+		for i, part := range parts {
+			if p, ok := translations[part]; ok && p != "" {
+				part = p
+			}
+			t += part + segments[i+1]
+		}
+		return t
+	}
+	return s
+}
+
+// shown returns the copy of p shown to the user: with its help described
+// and its message and help translated. p itself keeps its English message,
+// which scripts answer and help is looked up by.
+func shown(p survey.Prompt) survey.Prompt {
+	var c survey.Prompt
+	switch p := p.(type) {
+	case *survey.Input:
+		v := *p
+		c = &v
+	case *survey.Password:
+		v := *p
+		c = &v
+	case *survey.Confirm:
+		v := *p
+		c = &v
+	case *survey.Select:
+		v := *p
+		c = &v
+	case *survey.MultiSelect:
+		v := *p
+		c = &v
+	default:
+		return p
+	}
+	describe(c)
+	localize(c)
+	return c
+}
+
+// localize translates the message, help and options of p.
+func localize(p survey.Prompt) {
+	if translations == nil {
+		return
+	}
+	switch p := p.(type) {
+	case *survey.Input:
+		p.Message, p.Help = T(p.Message), T(p.Help)
+	case *survey.Password:
+		p.Message, p.Help = T(p.Message), T(p.Help)
+	case *survey.Confirm:
+		p.Message, p.Help = T(p.Message), T(p.Help)
+	case *survey.Select:
+		p.Message, p.Help = T(p.Message), T(p.Help)
+		p.Options = translateAll(p.Options)
+		if d, ok := p.Default.(string); ok {
+			p.Default = T(d)
+		}
+	case *survey.MultiSelect:
+		p.Message, p.Help = T(p.Message), T(p.Help)
+		p.Options = translateAll(p.Options)
+		if d, ok := p.Default.([]string); ok {
+			p.Default = translateAll(d)
+		}
+	}
+}
+
+func translateAll(texts []string) []string {
+	translated := make([]string, len(texts))
+	for i, s := range texts {
+		translated[i] = T(s)
+	}
+	return translated
+}
+
+// english maps the options selected in the translated copy of p back to the
+// options of p, which the answers are stored and compared as.
+func english(p survey.Prompt, ans interface{}) interface{} {
+	var options []string
+	switch p := p.(type) {
+	case *survey.Select:
+		options = p.Options
+	case *survey.MultiSelect:
+		options = p.Options
+	default:
+		return ans
+	}
+	untranslate := func(o core.OptionAnswer) core.OptionAnswer {
+		if o.Index >= 0 && o.Index < len(options) {
+			o.Value = options[o.Index]
+		}
+		return o
+	}
+	switch ans := ans.(type) {
+	case core.OptionAnswer:
+		return untranslate(ans)
+	case []core.OptionAnswer:
+		answers := make([]core.OptionAnswer, len(ans))
+		for i, o := range ans {
+			answers[i] = untranslate(o)
+		}
+		return answers
+	}
+	return ans
+}
@@ -0,0 +1,424 @@
+package prompts
+
+// catalogDE translates the prompts to German. Options naming kinds, fields,
+// values and tools, such as Deployment or STRICT, are left in English.
+var catalogDE = map[string]string{
+	"* (empty to finish)":           "* (leer zum Beenden)",
+	"* CPU limit (optional):":       "CPU-Limit für * (optional):",
+	"* CPU request:":                "CPU-Anforderung für *:",
+	"* hostnames for * (optional):": "Hostnamen (*) für * (optional):",
+	"* memory limit (optional):":    "Speicherlimit für * (optional):",
+	"* memory request:":             "Speicheranforderung für *:",
+	"* replicas:":                   "Replikate für *:",
+	"A ClusterIssuer serves all namespaces, an Issuer only its own.":                                                          "Ein ClusterIssuer bedient alle Namespaces, ein Issuer nur seinen eigenen.",
+	"A class provisioning an internal load balancer.":                                                                         "Eine Klasse, die einen internen Load Balancer bereitstellt.",
+	"A class provisioning an internet-facing load balancer.":                                                                  "Eine Klasse, die einen aus dem Internet erreichbaren Load Balancer bereitstellt.",
+	"A dashboard of the app's CPU and memory, labeled grafana_dashboard for the Grafana sidecar to load.":                     "Ein Dashboard zu CPU und Speicher der Anwendung, mit dem Label grafana_dashboard, damit der Grafana-Sidecar es lädt.",
+	"A dotted path to the field to set, e.g. spec.replicas.":                                                                  "Ein Pfad mit Punkten zum zu setzenden Feld, z. B. spec.replicas.",
+	"A field path such as spec.template.spec.containers.[name=app].env.[name=DB_HOST].value.":                                 "Ein Feldpfad wie spec.template.spec.containers.[name=app].env.[name=DB_HOST].value.",
+	"A file becoming one key. The key defaults to the file name.":                                                             "Eine Datei, die zu einem Schlüssel wird. Der Schlüssel ist standardmäßig der Dateiname.",
+	"A generator reads the values from files kept out of git. Sealed Secrets and SOPS encrypt them so they can be committed.": "Ein Generator liest die Werte aus Dateien, die nicht in git liegen. Sealed Secrets und SOPS verschlüsseln sie, sodass sie committet werden können.",
+	"A git or HTTP kustomize URL, e.g. https://github.com/org/repo//deploy/base?ref=v1.2.0.":                                  "Eine git- oder HTTP-URL für kustomize, z. B. https://github.com/org/repo//deploy/base?ref=v1.2.0.",
+	"A key of the ConfigMap with its value.":                                                                                  "Ein Schlüssel der ConfigMap mit seinem Wert.",
+	"A key of the Secret. Its value is asked next and not echoed.":                                                            "Ein Schlüssel des Secrets. Sein Wert wird danach abgefragt und nicht angezeigt.",
+	"A new branch": "Einen neuen Branch",
+	"A new branch holds the commit ready for a pull request; the current branch gets it directly.":                 "Ein neuer Branch hält den Commit für einen Pull Request bereit; der aktuelle Branch erhält ihn direkt.",
+	"A number of pods, or a percentage of the replicas such as 50%.":                                               "Eine Anzahl Pods oder ein Prozentsatz der Replikate wie 50%.",
+	"A strategic merge patch is a partial resource merged into it. A JSON patch is a list of operations on paths.": "Ein Strategic-Merge-Patch ist eine Teilressource, die in sie eingemischt wird. Ein JSON-Patch ist eine Liste von Operationen auf Pfaden.",
+	"A tag such as 1.4.2, or a digest such as sha256:... which pins the exact image.":                              "Ein Tag wie 1.4.2 oder ein Digest wie sha256:..., der das genaue Image festlegt.",
+	"ACME account email (for a generated ACME issuer):":                                                            "E-Mail des ACME-Kontos (für einen erzeugten ACME-Issuer):",
+	"ACME requests certificates from e.g. Let's Encrypt, self-signed suits tests.":                                 "ACME fordert Zertifikate z. B. bei Let's Encrypt an, selbstsigniert eignet sich für Tests.",
+	"API permissions:":                         "API-Berechtigungen:",
+	"Abort":                                    "Abbrechen",
+	"Add a Helm chart?":                        "Ein Helm-Chart hinzufügen?",
+	"Add a configMapGenerator entry?":          "Einen configMapGenerator-Eintrag hinzufügen?",
+	"Add a patch?":                             "Einen Patch hinzufügen?",
+	"Add a replacement?":                       "Eine Ersetzung hinzufügen?",
+	"Add a secret?":                            "Ein Secret hinzufügen?",
+	"Add another Helm chart?":                  "Ein weiteres Helm-Chart hinzufügen?",
+	"Add another configMapGenerator entry?":    "Einen weiteren configMapGenerator-Eintrag hinzufügen?",
+	"Add another patch?":                       "Einen weiteren Patch hinzufügen?",
+	"Add another replacement?":                 "Eine weitere Ersetzung hinzufügen?",
+	"Add another secret?":                      "Ein weiteres Secret hinzufügen?",
+	"Add the labels to pod templates?":         "Die Labels zu Pod-Templates hinzufügen?",
+	"Add the labels to selectors?":             "Die Labels zu Selektoren hinzufügen?",
+	"Additional environment (empty to finish)": "Weitere Umgebung (leer zum Beenden)",
+	"Adds a Secret, generated from files or stored encrypted in the repository.":             "Fügt ein Secret hinzu, erzeugt aus Dateien oder verschlüsselt im Repository abgelegt.",
+	"Adds a label to all resources.":                                                         "Fügt allen Ressourcen ein Label hinzu.",
+	"Adds an annotation to all resources.":                                                   "Fügt allen Ressourcen eine Annotation hinzu.",
+	"Adds an overlay for an environment not listed.":                                         "Fügt ein Overlay für eine nicht aufgeführte Umgebung hinzu.",
+	"Adds another Secret.":                                                                   "Fügt ein weiteres Secret hinzu.",
+	"Adds another patch.":                                                                    "Fügt einen weiteren Patch hinzu.",
+	"Adds clusters/<name>/<env> overlays on top of the environment overlays.":                "Fügt Overlays clusters/<name>/<env> über den Overlays der Umgebungen hinzu.",
+	"Adds the CustomResourceDefinitions in the chart's crds/ directory to the output.":       "Nimmt die CustomResourceDefinitions im Verzeichnis crds/ des Charts in die Ausgabe auf.",
+	"Adds the Namespace itself to the base, for clusters where it is not created otherwise.": "Fügt den Namespace selbst zur Basis hinzu, für Cluster, in denen er nicht anderweitig angelegt wird.",
+	"Allow requests through the ingress gateways?":                                           "Anfragen über die Ingress-Gateways erlauben?",
+	"Allow traffic from pods in the same namespace?":                                         "Verkehr von Pods im selben Namespace erlauben?",
+	"Allowed egress:": "Erlaubter ausgehender Verkehr:",
+	"Allowed principals, comma separated (optional):":                                  "Erlaubte Principals, durch Kommas getrennt (optional):",
+	"Allowed source namespaces, comma separated (optional):":                           "Erlaubte Quell-Namespaces, durch Kommas getrennt (optional):",
+	"Allows connections from all pods in these namespaces.":                            "Erlaubt Verbindungen von allen Pods in diesen Namespaces.",
+	"Allows connections from all pods of the namespace.":                               "Erlaubt Verbindungen von allen Pods des Namespaces.",
+	"Allows connections from these address ranges, e.g. a load balancer subnet.":       "Erlaubt Verbindungen aus diesen Adressbereichen, z. B. einem Subnetz von Load Balancern.",
+	"Allows connections to these address ranges, e.g. a database subnet.":              "Erlaubt Verbindungen zu diesen Adressbereichen, z. B. einem Datenbank-Subnetz.",
+	"Allows requests coming in through the Istio ingress gateways.":                    "Erlaubt Anfragen, die über die Istio-Ingress-Gateways eingehen.",
+	"Allows requests from all workloads in these namespaces.":                          "Erlaubt Anfragen von allen Workloads in diesen Namespaces.",
+	"An http(s) chart repository or an oci:// registry.":                               "Ein http(s)-Chart-Repository oder eine oci://-Registry.",
+	"Answers the common steps with a known setup, which later steps can still change.": "Beantwortet die üblichen Schritte mit einem bekannten Aufbau, den spätere Schritte noch ändern können.",
+	"Appended to the names of all resources, and to the references to them.":           "Wird an die Namen aller Ressourcen und an die Verweise auf sie angehängt.",
+	"Application name:": "Name der Anwendung:",
+	"Applies one overlay with kubectl after writing the files.":                   "Wendet nach dem Schreiben der Dateien ein Overlay mit kubectl an.",
+	"Applies the build output with kubectl apply --server-side.":                  "Wendet die Ausgabe von kustomize build mit kubectl apply --server-side an.",
+	"Apply the manifests to a cluster?":                                           "Manifeste auf einen Cluster anwenden?",
+	"Apply the mode to:":                                                          "Modus anwenden auf:",
+	"Asks the step again. The steps after it are undone and asked again as well.": "Fragt den Schritt erneut ab. Die Schritte danach werden rückgängig gemacht und ebenfalls erneut abgefragt.",
+	"Back": "Zurück",
+	"Bootstraps an app without manifests with a Deployment, StatefulSet, CronJob or Job.": "Legt für eine Anwendung ohne Manifeste ein Deployment, StatefulSet, einen CronJob oder Job an.",
+	"Branch to create:": "Anzulegender Branch:",
+	"Branch:":           "Branch:",
+	"CIDRs allowed to connect, comma separated (optional):": "CIDRs, die sich verbinden dürfen, durch Kommas getrennt (optional):",
+	"Change a step": "Einen Schritt ändern",
+	"Changes fields of the resources, by strategic merge or JSON patch.": "Ändert Felder der Ressourcen, per Strategic Merge oder JSON-Patch.",
+	"Chart name:":           "Name des Charts:",
+	"Chart repository URL:": "URL des Chart-Repositorys:",
+	"Chart version:":        "Version des Charts:",
+	"Comma separated name=weight pairs. The weights must sum to 100. Each subset needs a workload of its own, whose pods carry its name in the label asked next.": "Durch Kommas getrennte Paare name=gewicht. Die Gewichte müssen zusammen 100 ergeben. Jede Teilmenge braucht einen eigenen Workload, dessen Pods ihren Namen im danach abgefragten Label tragen.",
+	"Commit on:":                                                "Committen auf:",
+	"Commit the generated files to git?":                        "Erzeugte Dateien in git committen?",
+	"Common annotation KEY=VALUE (empty to finish)":             "Gemeinsame Annotation KEY=VALUE (leer zum Beenden)",
+	"Common labels:":                                            "Gemeinsame Labels:",
+	"Common sets of permissions granted to the ServiceAccount.": "Übliche Sätze von Berechtigungen, die dem ServiceAccount gewährt werden.",
+	"Completions:":                                              "Abschlüsse:",
+	"Components for *:":                                         "Komponenten für *:",
+	"Concurrency policy:":                                       "Richtlinie für gleichzeitige Läufe:",
+	"ConfigMap name:":                                           "Name der ConfigMap:",
+	"Configure Istio sidecar injection?":                        "Injektion von Istio-Sidecars konfigurieren?",
+	"Confirm":                                                   "Bestätigen",
+	"Confirm builds, validates and previews the files before writing them. Change a step goes back to it, Abort writes nothing.": "Bestätigen baut, prüft und zeigt die Dateien vor dem Schreiben an. Einen Schritt ändern kehrt zu ihm zurück, Abbrechen schreibt nichts.",
+	"Container image:":                    "Container-Image:",
+	"Container name:":                     "Name des Containers:",
+	"Container port to allow traffic to:": "Container-Port, zu dem Verkehr erlaubt ist:",
+	"Container port:":                     "Container-Port:",
+	"Continue:":                           "Weiter:",
+	"Copies another field.":               "Kopiert ein weiteres Feld.",
+	"Copies the value of a field of one resource into fields of others, e.g. a ConfigMap value into an env var. Replaces kustomize vars.": "Kopiert den Wert eines Feldes einer Ressource in Felder anderer, z. B. einen Wert einer ConfigMap in eine Umgebungsvariable. Ersetzt die vars von kustomize.",
+	"Counts from 0, the part before the first delimiter.": "Zählt ab 0, dem Teil vor dem ersten Trennzeichen.",
+	"Create Events":                                                          "Events anlegen",
+	"Create dedicated Gateways for the app?":                                 "Eigene Gateways für die Anwendung anlegen?",
+	"Created from the current branch, which is left as it is.":               "Wird vom aktuellen Branch abgezweigt, der unverändert bleibt.",
+	"Creates components/<name> to be filled in by hand.":                     "Legt components/<name> an, das von Hand gefüllt wird.",
+	"DNS names, comma separated (optional):":                                 "DNS-Namen, durch Kommas getrennt (optional):",
+	"Defaults to <app>-tls.":                                                 "Standardmäßig <app>-tls.",
+	"Defaults to <app>.internal.example.com.":                                "Standardmäßig <app>.internal.example.com.",
+	"Defaults to the application name.":                                      "Standardmäßig der Name der Anwendung.",
+	"Defaults to the hosts of the selected exposures.":                       "Standardmäßig die Hosts der gewählten Erreichbarkeiten.",
+	"Delimiter to replace part of the values (optional):":                    "Trennzeichen, um einen Teil der Werte zu ersetzen (optional):",
+	"Denies requests to the app unless a policy allows them.":                "Lehnt Anfragen an die Anwendung ab, sofern keine Richtlinie sie erlaubt.",
+	"Denies traffic to and from the app's pods except what is allowed next.": "Sperrt Verkehr zu und von den Pods der Anwendung bis auf das, was danach erlaubt wird.",
+	"Deploy the overlays with:":                                              "Overlays ausrollen mit:",
+	"Deployment name:":                                                       "Name des Deployments:",
+	"Deployments and StatefulSets get a Service listening on port 80, StatefulSets also a volume per pod. CronJobs and Jobs run to completion.": "Deployments und StatefulSets erhalten einen Service auf Port 80, StatefulSets zudem ein Volume je Pod. CronJobs und Jobs laufen bis zum Abschluss.",
+	"Deselected ConfigMaps are no longer generated.":                                        "Abgewählte ConfigMaps werden nicht mehr erzeugt.",
+	"Deselected Secrets are no longer generated.":                                           "Abgewählte Secrets werden nicht mehr erzeugt.",
+	"Deselected annotations are removed from the kustomization.":                            "Abgewählte Annotationen werden aus der Kustomization entfernt.",
+	"Deselected charts are removed from the kustomization.":                                 "Abgewählte Charts werden aus der Kustomization entfernt.",
+	"Deselected clusters have their overlays removed from the layout.":                      "Die Overlays abgewählter Cluster werden aus dem Aufbau entfernt.",
+	"Deselected patches are removed from the kustomization. Their files are left in place.": "Abgewählte Patches werden aus der Kustomization entfernt. Ihre Dateien bleiben erhalten.",
+	"Deselected remote bases are removed from the resources.":                               "Abgewählte entfernte Basen werden aus den Ressourcen entfernt.",
+	"Deselected replacements are removed from the kustomization.":                           "Abgewählte Ersetzungen werden aus der Kustomization entfernt.",
+	"Directory with existing manifests (optional):":                                         "Verzeichnis mit vorhandenen Manifesten (optional):",
+	"Disable the name suffix hash on generated resources?":                                  "Hash-Suffix im Namen erzeugter Ressourcen abschalten?",
+	"Disruption budget:": "Disruption Budget:",
+	"Docs:":              "Doku:",
+	"Does the app expose Prometheus metrics?": "Stellt die Anwendung Prometheus-Metriken bereit?",
+	"Domain (optional):":                      "Domain (optional):",
+	"E.g. 1-22 or a revision tag such as stable. Empty uses the default revision.": "Z. B. 1-22 oder ein Revisions-Tag wie stable. Leer verwendet die Standardrevision.",
+	"E.g. : replaces the host of db:5432 with index 0, keeping the port.":          "Z. B. ersetzt : mit Index 0 den Host von db:5432 und behält den Port.",
+	"E.g. deployments.apps:get,list;pods/log:get;deployments/scale.apps:update":    "Z. B. deployments.apps:get,list;pods/log:get;deployments/scale.apps:update",
+	"E.g. eu.example.com serves app.example.com as app.eu.example.com.":            "Z. B. stellt eu.example.com app.example.com als app.eu.example.com bereit.",
+	"E.g. release=prometheus for kube-prometheus-stack.":                           "Z. B. release=prometheus für kube-prometheus-stack.",
+	"Each environment becomes an overlay of the base under overlays/<env>.":        "Jede Umgebung wird zu einem Overlay der Basis unter overlays/<env>.",
+	"Empty when the tree is at the repository root.":                               "Leer, wenn der Baum im Wurzelverzeichnis des Repositorys liegt.",
+	"Enables or disables injection for the app.":                                   "Schaltet die Injektion für die Anwendung ein oder aus.",
+	"Entering a branch keeps the base unpinned.":                                   "Die Angabe eines Branches lässt die Basis unfixiert.",
+	"Env file (empty to finish)":                                                   "Env-Datei (leer zum Beenden)",
+	"Environment variables KEY=VALUE, comma separated (optional):":                 "Umgebungsvariablen KEY=VALUE, durch Kommas getrennt (optional):",
+	"Environments on *:": "Umgebungen auf *:",
+	"Environments:":      "Umgebungen:",
+	"Exact paths, or prefixes such as /api/*. Empty allows all paths.": "Genaue Pfade oder Präfixe wie /api/*. Leer erlaubt alle Pfade.",
+	"Example:": "Beispiel:",
+	"Expose the app publicly, privately or both:":                                                   "Anwendung öffentlich, privat oder beides bereitstellen:",
+	"Failed pods are restarted with an exponential back-off until this many retries.":               "Fehlgeschlagene Pods werden mit exponentiell wachsender Wartezeit bis zu so vielen Wiederholungen neu gestartet.",
+	"Field path (empty to finish)":                                                                  "Feldpfad (leer zum Beenden)",
+	"Fields of * to replace:":                                                                       "Zu ersetzende Felder von *:",
+	"File source [KEY=]PATH (empty to finish)":                                                      "Dateiquelle [KEY=]PATH (leer zum Beenden)",
+	"Forbid skips a run while the previous one is still running, Replace cancels the previous one.": "Forbid überspringt einen Lauf, solange der vorige noch läuft, Replace bricht den vorigen ab.",
+	"GatewayClass of dedicated private Gateways:":                                                   "GatewayClass eigener privater Gateways:",
+	"GatewayClass of dedicated public Gateways:":                                                    "GatewayClass eigener öffentlicher Gateways:",
+	"Generate AuthorizationPolicies denying all but explicitly allowed requests?":                   "AuthorizationPolicies erzeugen, die alle nicht ausdrücklich erlaubten Anfragen ablehnen?",
+	"Generate NetworkPolicies?":                                                                     "NetworkPolicies erzeugen?",
+	"Generate a Grafana dashboard ConfigMap?":                                                       "Eine ConfigMap mit einem Grafana-Dashboard erzeugen?",
+	"Generate a Namespace manifest for *?":                                                          "Ein Namespace-Manifest für * erzeugen?",
+	"Generate a PodDisruptionBudget and topology spread constraints?":                               "Ein PodDisruptionBudget und Topology Spread Constraints erzeugen?",
+	"Generate a workload for the app?":                                                              "Einen Workload für die Anwendung erzeugen?",
+	"Generate fix patches for:":                                                                     "Korrektur-Patches erzeugen für:",
+	"Generate the issuer as well?":                                                                  "Den Issuer ebenfalls erzeugen?",
+	"Generate these files?":                                                                         "Diese Dateien erzeugen?",
+	"Generated resources and patches are merged into their kustomization.yaml, keeping comments and ordering. The other overlays are left as they are.": "Erzeugte Ressourcen und Patches werden in ihre kustomization.yaml eingefügt, wobei Kommentare und Reihenfolge erhalten bleiben. Die anderen Overlays bleiben unverändert.",
+	"Generated resources and patches are merged into their kustomization.yaml. The other overlays are left as they are.":                                "Erzeugte Ressourcen und Patches werden in ihre kustomization.yaml eingefügt. Die anderen Overlays bleiben unverändert.",
+	"Generates PeerAuthentications requiring or permitting mutual TLS.":                                                                                 "Erzeugt PeerAuthentications, die gegenseitiges TLS verlangen oder zulassen.",
+	"Generates a Certificate for the hosts and serves them over HTTPS.":                                                                                 "Erzeugt ein Certificate für die Hosts und stellt sie über HTTPS bereit.",
+	"Generates a ClusterRole and ClusterRoleBinding instead of a Role and RoleBinding.":                                                                 "Erzeugt eine ClusterRole und ClusterRoleBinding statt einer Role und RoleBinding.",
+	"Generates a ConfigMap from literals and files, named with a hash of its data so pods roll when it changes.":                                        "Erzeugt eine ConfigMap aus Literalen und Dateien, benannt mit einem Hash ihrer Daten, sodass Pods bei Änderungen neu ausgerollt werden.",
+	"Generates a Prometheus Operator monitor scraping them, and optionally a Grafana dashboard.":                                                        "Erzeugt einen Monitor des Prometheus Operators, der sie abruft, und optional ein Grafana-Dashboard.",
+	"Generates a ServiceAccount with only the permissions the app needs.":                                                                               "Erzeugt einen ServiceAccount mit nur den Berechtigungen, die die Anwendung braucht.",
+	"Generates an Argo CD Application or Flux Kustomization per overlay.":                                                                               "Erzeugt je Overlay eine Argo-CD-Application oder Flux-Kustomization.",
+	"Generates another ConfigMap.":                                      "Erzeugt eine weitere ConfigMap.",
+	"Git repository URL:":                                               "URL des Git-Repositorys:",
+	"Grant the permissions cluster-wide?":                               "Die Berechtigungen clusterweit gewähren?",
+	"HTTP probe path:":                                                  "HTTP-Pfad der Probes:",
+	"Health probes:":                                                    "Health-Probes:",
+	"How many pods must succeed for the Job to complete.":               "Wie viele Pods erfolgreich sein müssen, damit der Job abgeschlossen ist.",
+	"How many pods run at once.":                                        "Wie viele Pods gleichzeitig laufen.",
+	"How often Prometheus scrapes the app. Overlays can set their own.": "Wie oft Prometheus die Anwendung abruft. Overlays können einen eigenen Wert setzen.",
+	"How should * be stored?":                                           "Wie soll * gespeichert werden?",
+	"How uneven the spread may get.":                                    "Wie ungleichmäßig die Verteilung werden darf.",
+	"Images to override in *:":                                          "In * zu überschreibende Images:",
+	"Include the chart's CRDs?":                                         "Die CRDs des Charts einbinden?",
+	"Index of the part to replace:":                                     "Index des zu ersetzenden Teils:",
+	"Inflates a chart into the base. kustomize build then needs --enable-helm.": "Rendert ein Chart in die Basis. kustomize build braucht dann --enable-helm.",
+	"Inflates another chart into the base.":                                     "Rendert ein weiteres Chart in die Basis.",
+	"Inject sidecars?":                                                          "Sidecars injizieren?",
+	"Internet excludes the private address ranges.":                             "Internet schließt die privaten Adressbereiche aus.",
+	"Intervals other than the base's are patched into the overlay.":             "Intervalle, die von dem der Basis abweichen, werden in das Overlay gepatcht.",
+	"Issuer kind:":                     "Art des Issuers:",
+	"Issuer name:":                     "Name des Issuers:",
+	"Issuer type:":                     "Typ des Issuers:",
+	"Istio revision for * (optional):": "Istio-Revision für * (optional):",
+	"Keep Helm charts:":                "Helm-Charts behalten:",
+	"Keep clusters:":                   "Cluster behalten:",
+	"Keep common annotations:":         "Gemeinsame Annotationen behalten:",
+	"Keep configMapGenerator entries:": "configMapGenerator-Einträge behalten:",
+	"Keep patches:":                    "Patches behalten:",
+	"Keep pods pending rather than violate the spread?": "Pods lieber wartend lassen, als die Verteilung zu verletzen?",
+	"Keep remote bases:":            "Entfernte Basen behalten:",
+	"Keep replacements:":            "Ersetzungen behalten:",
+	"Keep secretGenerator entries:": "secretGenerator-Einträge behalten:",
+	"Keeps the app available during node drains and spreads its pods across failure domains.": "Hält die Anwendung beim Leeren von Knoten verfügbar und verteilt ihre Pods über Ausfallbereiche.",
+	"Kube context:": "Kube-Kontext:",
+	"Label KEY=VALUE for generated resources (empty to finish)": "Label KEY=VALUE für erzeugte Ressourcen (leer zum Beenden)",
+	"Label the:": "Label setzen am:",
+	"Labels Prometheus selects monitors by, KEY=VALUE comma separated (optional):":          "Labels, nach denen Prometheus Monitore auswählt, KEY=VALUE durch Kommas getrennt (optional):",
+	"Labels added to the generated ConfigMaps and Secrets only.":                            "Labels, die nur den erzeugten ConfigMaps und Secrets hinzugefügt werden.",
+	"Labels the namespace or the pods to get, or not get, an Istio sidecar.":                "Versieht den Namespace oder die Pods mit einem Label, damit sie einen Istio-Sidecar erhalten oder nicht.",
+	"Lands the scaffolding as a reviewable change, with the answers in the commit message.": "Legt das Gerüst als prüfbare Änderung ab, mit den Antworten in der Commit-Nachricht.",
+	"Leader election (Leases)":                                           "Leader-Wahl (Leases)",
+	"Leave empty for the cluster's default storage class.":               "Leer lassen für die Standard-Storage-Class des Clusters.",
+	"Leave off when the issuer already exists in the cluster.":           "Weglassen, wenn der Issuer im Cluster bereits existiert.",
+	"Lets pods, and metrics and logs taken from them, carry the labels.": "Lässt Pods und die von ihnen erfassten Metriken und Logs die Labels tragen.",
+	"Lists the tags in the registry to choose from.":                     "Listet die Tags in der Registry zur Auswahl auf.",
+	"Literal KEY=VALUE (empty to finish)":                                "Literal KEY=VALUE (leer zum Beenden)",
+	"Look up tags of *?":                                                 "Tags von * nachschlagen?",
+	"Manage Jobs":                                                        "Jobs verwalten",
+	"Maximum pod count difference between domains:":                      "Größter Unterschied der Pod-Anzahl zwischen Bereichen:",
+	"Metrics path:":                                                      "Pfad der Metriken:",
+	"Metrics port name:":                                                 "Name des Metrik-Ports:",
+	"Minute, hour, day of month, month and day of week, in the time zone of the controller manager. Macros such as @daily work too.": "Minute, Stunde, Tag des Monats, Monat und Wochentag, in der Zeitzone des Controller Managers. Makros wie @daily funktionieren auch.",
+	"Name prefix (optional):": "Namenspräfix (optional):",
+	"Name suffix (optional):": "Namenssuffix (optional):",
+	"Namespace applies it to every workload in the overlay's namespace.":   "Namespace wendet ihn auf jeden Workload im Namespace des Overlays an.",
+	"Namespace labels need the Namespace to be one of the base resources.": "Labels am Namespace setzen voraus, dass der Namespace eine der Ressourcen der Basis ist.",
+	"Namespace:":                            "Namespace:",
+	"New empty component (empty to finish)": "Neue leere Komponente (leer zum Beenden)",
+	"New name for * (optional):":            "Neuer Name für * (optional):",
+	"New tag or digest:":                    "Neuer Tag oder Digest:",
+	"Next":                                  "Weiter",
+	"Next goes on to the next step, Back undoes the previous step and asks it again, Redo asks this step again.": "Weiter geht zum nächsten Schritt, Zurück macht den vorigen Schritt rückgängig und fragt ihn erneut ab, Diesen Schritt wiederholen fragt diesen Schritt erneut ab.",
+	"Node selector KEY=VALUE for * (empty to finish)":                                                            "Node-Selektor KEY=VALUE für * (leer zum Beenden)",
+	"Nodes": "Knoten",
+	"None":  "Keine",
+	"Older versions get the older forms of newer fields, such as patchesStrategicMerge for patches. Versions that cannot express the kustomizations are not offered.": "Ältere Versionen erhalten die älteren Formen neuerer Felder, etwa patchesStrategicMerge für patches. Versionen, die die Kustomizations nicht ausdrücken können, werden nicht angeboten.",
+	"Operation:":             "Operation:",
+	"Other (enter manually)": "Andere (von Hand eingeben)",
+	"Other CIDRs the app connects to, comma separated (optional):":     "Weitere CIDRs, zu denen die Anwendung sich verbindet, durch Kommas getrennt (optional):",
+	"Other common label KEY=VALUE (empty to finish)":                   "Weiteres gemeinsames Label KEY=VALUE (leer zum Beenden)",
+	"Other field of * (empty to finish)":                               "Weiteres Feld von * (leer zum Beenden)",
+	"Other image to override in * (empty to finish)":                   "Weiteres in * zu überschreibendes Image (leer zum Beenden)",
+	"Other namespaces allowed to connect, comma separated (optional):": "Weitere Namespaces, die sich verbinden dürfen, durch Kommas getrennt (optional):",
+	"Other rules, separated by ; (optional):":                          "Weitere Regeln, durch ; getrennt (optional):",
+	"Otherwise the routes attach to the shared gateway-system/public and private gateways, which a ReferenceGrant lets use the app's TLS secret.": "Andernfalls hängen die Routen an den gemeinsamen Gateways gateway-system/public und private, denen ein ReferenceGrant das TLS-Secret der Anwendung zugänglich macht.",
+	"Otherwise the routes attach to the shared gateways.": "Andernfalls hängen die Routen an den gemeinsamen Gateways.",
+	"Overlay to apply:":                               "Anzuwendendes Overlay:",
+	"Overlays to modify:":                             "Zu ändernde Overlays:",
+	"Overrides the base hostnames * in this overlay.": "Überschreibt die Hostnamen * der Basis in diesem Overlay.",
+	"Overrides the base hostnames in this overlay.":   "Überschreibt die Hostnamen der Basis in diesem Overlay.",
+	"Owning team (optional):":                         "Verantwortliches Team (optional):",
+	"Parallelism:":                                    "Parallelität:",
+	"Patch target:":                                   "Ziel des Patches:",
+	"Patch type:":                                     "Art des Patches:",
+	"Patches each overlay with its own replica count and container requests and limits.":                            "Patcht jedes Overlay mit eigener Anzahl Replikate sowie eigenen Anforderungen und Limits der Container.",
+	"Path of the tree in the repository:":                                                                           "Pfad des Baums im Repository:",
+	"Path or URL of the controller's public certificate. When empty, kubeseal fetches it from the current cluster.": "Pfad oder URL des öffentlichen Zertifikats des Controllers. Wenn leer, holt kubeseal es aus dem aktuellen Cluster.",
+	"Path to a file of KEY=VALUE lines, each becoming a key.":                                                       "Pfad zu einer Datei mit Zeilen KEY=VALUE, die jeweils zu einem Schlüssel werden.",
+	"Path to a file of KEY=VALUE lines.":                                                                            "Pfad zu einer Datei mit Zeilen KEY=VALUE.",
+	"Path to a file of KEY=VALUE lines. Keep it out of version control.":                                            "Pfad zu einer Datei mit Zeilen KEY=VALUE. Nicht in die Versionskontrolle aufnehmen.",
+	"Path:": "Pfad:",
+	"Paths they may request, comma separated (optional):":                     "Pfade, die sie anfragen dürfen, durch Kommas getrennt (optional):",
+	"Pin to tag or commit:":                                                   "Auf Tag oder Commit festlegen:",
+	"Pins pods to nodes labelled topology.kubernetes.io/region.":              "Bindet Pods an Knoten mit dem Label topology.kubernetes.io/region.",
+	"Pod label holding the subset name:":                                      "Pod-Label mit dem Namen der Teilmenge:",
+	"Pods of each version carry this label with the subset name as value.":    "Die Pods jeder Version tragen dieses Label mit dem Namen der Teilmenge als Wert.",
+	"Pods, as a count or percentage:":                                         "Pods, als Anzahl oder Prozentsatz:",
+	"Prepended to the names of all resources, and to the references to them.": "Wird den Namen aller Ressourcen und den Verweisen auf sie vorangestellt.",
+	"Preview the kustomize build output?":                                     "Ausgabe von kustomize build anzeigen?",
+	"Private":                                                                 "Privat",
+	"Private hostnames, comma separated (optional):":                          "Private Hostnamen, durch Kommas getrennt (optional):",
+	"Probes check the first container port, limits default to 500m CPU and 512Mi memory. Review the patches before applying.": "Die Probes prüfen den ersten Container-Port, die Limits sind standardmäßig 500m CPU und 512Mi Speicher. Die Patches vor dem Anwenden prüfen.",
+	"Public": "Öffentlich",
+	"Public hostnames, comma separated (optional):":                                        "Öffentliche Hostnamen, durch Kommas getrennt (optional):",
+	"Public routes through the internet-facing gateway, Private through the internal one.": "Öffentlich leitet über das aus dem Internet erreichbare Gateway, Privat über das interne.",
+	"Read ConfigMaps":               "ConfigMaps lesen",
+	"Read Deployments/StatefulSets": "Deployments/StatefulSets lesen",
+	"Read Pods":                     "Pods lesen",
+	"Read Secrets":                  "Secrets lesen",
+	"Read Services and Endpoints":   "Services und Endpoints lesen",
+	"Readiness probes keep traffic away from pods that are not ready, liveness probes restart pods that hang.": "Readiness-Probes halten Verkehr von Pods fern, die nicht bereit sind, Liveness-Probes starten hängende Pods neu.",
+	"Recommended labels added to all resources.":                                                               "Empfohlene Labels, die allen Ressourcen hinzugefügt werden.",
+	"Redo this step":                     "Diesen Schritt wiederholen",
+	"Region (optional):":                 "Region (optional):",
+	"Release name:":                      "Name des Releases:",
+	"Remote base URL (empty to finish):": "URL einer entfernten Basis (leer zum Beenden):",
+	"Replacement source:":                "Quelle der Ersetzung:",
+	"Replacement targets:":               "Ziele der Ersetzung:",
+	"Replaces the image name, e.g. to pull from a mirror registry.":             "Ersetzt den Namen des Images, z. B. um es von einer Spiegel-Registry zu beziehen.",
+	"Retries before the job fails:":                                             "Wiederholungen, bevor der Job fehlschlägt:",
+	"Routes a share of the requests to each version, e.g. for canary releases.": "Leitet einen Anteil der Anfragen zu jeder Version, z. B. für Canary-Releases.",
+	"Routing backend:": "Routing-Backend:",
+	"Run the app with a dedicated ServiceAccount?": "Die Anwendung mit einem eigenen ServiceAccount ausführen?",
+	"SOPS encrypted Secret":                        "Mit SOPS verschlüsseltes Secret",
+	"STRICT accepts mutual TLS only, PERMISSIVE plain text as well, e.g. while migrating.": "STRICT akzeptiert nur gegenseitiges TLS, PERMISSIVE auch Klartext, z. B. während einer Migration.",
+	"Same namespace":   "Gleicher Namespace",
+	"Schedule (cron):": "Zeitplan (cron):",
+	"Schedules the workloads only on nodes with the label.": "Plant die Workloads nur auf Knoten mit dem Label ein.",
+	"Scrape interval for *:":                                "Abrufintervall für *:",
+	"Scrape interval:":                                      "Abrufintervall:",
+	"Scrape with:":                                          "Abrufen mit:",
+	"Sealing certificate (optional):":                       "Zertifikat zum Versiegeln (optional):",
+	"Secret key (empty to finish)":                          "Schlüssel des Secrets (leer zum Beenden)",
+	"Secret name:":                                          "Name des Secrets:",
+	"Secret of type kubernetes.io/tls holding the certificate for the host.":  "Secret vom Typ kubernetes.io/tls mit dem Zertifikat für den Host.",
+	"Secret of type kubernetes.io/tls holding the certificate for the hosts.": "Secret vom Typ kubernetes.io/tls mit dem Zertifikat für die Hosts.",
+	"Secret type:":                 "Typ des Secrets:",
+	"Select resources to include:": "Einzubindende Ressourcen auswählen:",
+	"Selectors of Deployments cannot change once created, so only enable this for new apps.": "Selektoren von Deployments lassen sich nach dem Anlegen nicht mehr ändern, daher nur für neue Anwendungen einschalten.",
+	"Self-signed":                     "Selbstsigniert",
+	"Server-side apply * to *?":       "* serverseitig auf * anwenden?",
+	"ServiceAccount name (optional):": "Name des ServiceAccounts (optional):",
+	"ServiceMonitors scrape the pods behind the app's Service, PodMonitors the pods directly, e.g. of Jobs.": "ServiceMonitors rufen die Pods hinter dem Service der Anwendung ab, PodMonitors die Pods direkt, z. B. von Jobs.",
+	"Set on the container. Use a ConfigMap or Secret for values that differ per environment.":                "Wird am Container gesetzt. Für Werte, die je Umgebung abweichen, eine ConfigMap oder ein Secret verwenden.",
+	"Set replicas and resources per environment?":                                                            "Replikate und Ressourcen je Umgebung festlegen?",
+	"Set the Istio mTLS mode per environment?":                                                               "Den mTLS-Modus von Istio je Umgebung festlegen?",
+	"Sets the class of all claims in the cluster's overlays.":                                                "Setzt die Klasse aller Claims in den Overlays des Clusters.",
+	"Sets the namespace: field of the kustomization. Leave empty to keep the namespaces of the resources.":   "Setzt das Feld namespace: der Kustomization. Leer lassen, um die Namespaces der Ressourcen zu behalten.",
+	"Sets the team label.": "Setzt das Label team.",
+	"Sets whenUnsatisfiable to DoNotSchedule instead of ScheduleAnyway.": "Setzt whenUnsatisfiable auf DoNotSchedule statt ScheduleAnyway.",
+	"Shows what kustomize build renders for each overlay.":               "Zeigt, was kustomize build für jedes Overlay rendert.",
+	"Source field:": "Quellfeld:",
+	"Split traffic between versions of the app?":              "Verkehr auf Versionen der Anwendung aufteilen?",
+	"Spread pods across:":                                     "Pods verteilen über:",
+	"Start from a preset:":                                    "Mit einer Vorlage beginnen:",
+	"Step to change:":                                         "Zu ändernder Schritt:",
+	"Storage class (optional):":                               "Storage-Class (optional):",
+	"StorageClass of PersistentVolumeClaims (optional):":      "StorageClass der PersistentVolumeClaims (optional):",
+	"Subsets and weights:":                                    "Teilmengen und Gewichte:",
+	"TCP socket":                                              "TCP-Socket",
+	"TLS secret name (optional):":                             "Name des TLS-Secrets (optional):",
+	"Tag:":                                                    "Tag:",
+	"Target cluster (empty to finish)":                        "Zielcluster (leer zum Beenden)",
+	"Target group (empty for core):":                          "Gruppe des Ziels (leer für core):",
+	"Target kind:":                                            "Art des Ziels:",
+	"Target kustomize version:":                               "kustomize-Zielversion:",
+	"Target name:":                                            "Name des Ziels:",
+	"Target namespace:":                                       "Ziel-Namespace:",
+	"Target version:":                                         "Version des Ziels:",
+	"Terminate TLS with a cert-manager certificate?":          "TLS mit einem Zertifikat von cert-manager terminieren?",
+	"The ACME server sends expiry notices to this address.":   "Der ACME-Server schickt Hinweise zum Ablauf an diese Adresse.",
+	"The API group of the target, e.g. apps for Deployments.": "Die API-Gruppe des Ziels, z. B. apps für Deployments.",
+	"The API version of the target.":                          "Die API-Version des Ziels.",
+	"The CPU the container is throttled at.":                  "Die CPU, ab der der Container gedrosselt wird.",
+	"The CPU the scheduler reserves for the container, in cores or millicores such as 100m.":                                 "Die CPU, die der Scheduler für den Container reserviert, in Kernen oder Millicores wie 100m.",
+	"The Deployment the replicas and resources apply to.":                                                                    "Das Deployment, für das Replikate und Ressourcen gelten.",
+	"The Deployment, StatefulSet, CronJob or Job the resources, and the replicas of Deployments and StatefulSets, apply to.": "Das Deployment, StatefulSet, der CronJob oder Job, für den die Ressourcen und bei Deployments und StatefulSets die Replikate gelten.",
+	"The branch the GitOps tool follows.":                                                                                    "Der Branch, dem das GitOps-Werkzeug folgt.",
+	"The chart version to pin, so builds are repeatable.":                                                                    "Die festzulegende Version des Charts, damit Builds wiederholbar sind.",
+	"The chart's name in the repository.":                                                                                    "Der Name des Charts im Repository.",
+	"The cluster and user from the kubeconfig to apply with.":                                                                "Cluster und Benutzer aus der kubeconfig, mit denen angewendet wird.",
+	"The components the overlay includes.":                                                                                   "Die Komponenten, die das Overlay einbindet.",
+	"The container of the workload the requests and limits are set on.":                                                      "Der Container des Workloads, an dem Anforderungen und Limits gesetzt werden.",
+	"The current branch":                             "Den aktuellen Branch",
+	"The environment whose build output is applied.": "Die Umgebung, deren Build-Ausgabe angewendet wird.",
+	"The environments deployed to the cluster, each getting a cluster overlay.":                                             "Die auf den Cluster ausgerollten Umgebungen, die jeweils ein Cluster-Overlay erhalten.",
+	"The existing fields of the target that get the value.":                                                                 "Die vorhandenen Felder des Ziels, die den Wert erhalten.",
+	"The failure domains pods are spread across, by topology key.":                                                          "Die Ausfallbereiche, über die Pods verteilt werden, nach Topologie-Schlüssel.",
+	"The field whose value is copied.":                                                                                      "Das Feld, dessen Wert kopiert wird.",
+	"The image name as used in the manifests, without tag.":                                                                 "Der Name des Images, wie er in den Manifesten steht, ohne Tag.",
+	"The images whose name, tag or digest the overlay changes.":                                                             "Die Images, deren Namen, Tag oder Digest das Overlay ändert.",
+	"The ingress controller serving internal hosts.":                                                                        "Der Ingress-Controller für interne Hosts.",
+	"The ingress controller serving internet-facing hosts.":                                                                 "Der Ingress-Controller für aus dem Internet erreichbare Hosts.",
+	"The issuer the Certificates refer to.":                                                                                 "Der Issuer, auf den die Certificates verweisen.",
+	"The key defaults to the file name.":                                                                                    "Der Schlüssel ist standardmäßig der Dateiname.",
+	"The kind of the target.":                                                                                               "Die Art (kind) des Ziels.",
+	"The memory the container is killed at when it uses more.":                                                              "Der Speicher, bei dessen Überschreitung der Container beendet wird.",
+	"The memory the scheduler reserves for the container, such as 128Mi.":                                                   "Der Speicher, den der Scheduler für den Container reserviert, etwa 128Mi.",
+	"The name of the Service port, or of the container port for PodMonitors.":                                               "Der Name des Service-Ports, bei PodMonitors des Container-Ports.",
+	"The name of the target in the base.":                                                                                   "Der Name des Ziels in der Basis.",
+	"The name resources refer to the ConfigMap by. kustomize updates the references to the hashed name.":                    "Der Name, unter dem Ressourcen auf die ConfigMap verweisen. kustomize passt die Verweise auf den Namen mit Hash an.",
+	"The name resources refer to the Secret by.":                                                                            "Der Name, unter dem Ressourcen auf das Secret verweisen.",
+	"The number of pods in this environment.":                                                                               "Die Anzahl der Pods in dieser Umgebung.",
+	"The path answering 2xx or 3xx while the app is healthy.":                                                               "Der Pfad, der mit 2xx oder 3xx antwortet, solange die Anwendung gesund ist.",
+	"The path to match or change.":                                                                                          "Der zu treffende oder zu ändernde Pfad.",
+	"The port other pods connect to.":                                                                                       "Der Port, zu dem andere Pods sich verbinden.",
+	"The port the app listens on, named http for the probes and the Service.":                                               "Der Port, auf dem die Anwendung lauscht, für die Probes und den Service http genannt.",
+	"The release name the chart's templates see, usually part of the resource names.":                                       "Der Release-Name, den die Templates des Charts sehen, meist Teil der Ressourcennamen.",
+	"The repository the GitOps tool pulls the tree from.":                                                                   "Das Repository, aus dem das GitOps-Werkzeug den Baum holt.",
+	"The resource the patch applies to.":                                                                                    "Die Ressource, auf die der Patch angewendet wird.",
+	"The resource the value is copied from. Generated ConfigMaps are listed with the keys of their literals and env files.": "Die Ressource, aus der der Wert kopiert wird. Erzeugte ConfigMaps sind mit den Schlüsseln ihrer Literale und Env-Dateien aufgeführt.",
+	"The resources the value is copied into.":                                                                               "Die Ressourcen, in die der Wert kopiert wird.",
+	"The selected files are listed as resources of the base.":                                                               "Die gewählten Dateien werden als Ressourcen der Basis aufgeführt.",
+	"The tag the overlay deploys.":                                                                                          "Der Tag, den das Overlay ausrollt.",
+	"The value of the operation, as YAML.":                                                                                  "Der Wert der Operation, als YAML.",
+	"The value to set, as YAML: strings, numbers, lists and mappings are accepted.":                                         "Der zu setzende Wert, als YAML: Zeichenketten, Zahlen, Listen und Mappings sind möglich.",
+	"Types other than Opaque require certain keys, such as tls.crt and tls.key.":                                            "Andere Typen als Opaque verlangen bestimmte Schlüssel, etwa tls.crt und tls.key.",
+	"Used for objects without a namespace. Leave empty for the context's default.":                                          "Gilt für Objekte ohne Namespace. Leer lassen für den Standard des Kontexts.",
+	"Used for resource names and the app.kubernetes.io/name label.":                                                         "Wird für Ressourcennamen und das Label app.kubernetes.io/name verwendet.",
+	"Validate manifests against Kubernetes version:":                                                                        "Manifeste gegen Kubernetes-Version prüfen:",
+	"Value for *:":       "Wert für *:",
+	"Value:":             "Wert:",
+	"Volume mount path:": "Einhängepfad des Volumes:",
+	"Volume size:":       "Größe des Volumes:",
+	"What exposes the app: Istio Gateways and VirtualServices, Gateway API HTTPRoutes or Ingresses.": "Was die Anwendung bereitstellt: Istio-Gateways und VirtualServices, HTTPRoutes der Gateway API oder Ingresses.",
+	"When empty, SOPS uses the creation rules from .sops.yaml.":                                      "Wenn leer, verwendet SOPS die creation_rules aus .sops.yaml.",
+	"Where the volume claimed for each pod of the StatefulSet is mounted.":                           "Wo das für jeden Pod des StatefulSets beanspruchte Volume eingehängt wird.",
+	"Wildcards such as *.example.com are allowed. Defaults to <app>.example.com.":                    "Platzhalter wie *.example.com sind erlaubt. Standardmäßig <app>.example.com.",
+	"With a tag or digest, e.g. registry.example.com/shop:1.0.0.":                                    "Mit Tag oder Digest, z. B. registry.example.com/shop:1.0.0.",
+	"Without the hash, pods are not rolled when the generated data changes.":                         "Ohne den Hash werden Pods nicht neu ausgerollt, wenn sich die erzeugten Daten ändern.",
+	"Workload identities such as cluster.local/ns/shop/sa/frontend.":                                 "Workload-Identitäten wie cluster.local/ns/shop/sa/frontend.",
+	"Workload to size:":       "Zu dimensionierender Workload:",
+	"Workload type:":          "Art des Workloads:",
+	"Write the files anyway?": "Dateien trotzdem schreiben?",
+	"Write the files?":        "Dateien schreiben?",
+	"Writes the files despite the validation errors listed.":                                   "Schreibt die Dateien trotz der aufgeführten Validierungsfehler.",
+	"Writes the files to the output directory, replacing existing ones.":                       "Schreibt die Dateien in das Ausgabeverzeichnis und ersetzt vorhandene.",
+	"YAML files found below this directory can be included as resources of the kustomization.": "YAML-Dateien unterhalb dieses Verzeichnisses können als Ressourcen der Kustomization eingebunden werden.",
+	"Zones": "Zonen",
+	"add inserts a value, replace changes an existing one and remove deletes it.": "add fügt einen Wert ein, replace ändert einen vorhandenen und remove löscht ihn.",
+	"age recipient (optional):":             "age-Empfänger (optional):",
+	"done":                                  "fertig",
+	"ingressClassName for private traffic:": "ingressClassName für privaten Verkehr:",
+	"ingressClassName for public traffic:":  "ingressClassName für öffentlichen Verkehr:",
+	"kubeconform checks the build output against the API schemas of this version.": "kubeconform prüft die Build-Ausgabe gegen die API-Schemas dieser Version.",
+	"mTLS mode for *:": "mTLS-Modus für *:",
+	"minAvailable pods are kept running, or at most maxUnavailable are evicted at once, e.g. during node drains.": "minAvailable Pods laufen weiter, oder höchstens maxUnavailable werden gleichzeitig verdrängt, z. B. beim Leeren von Knoten.",
+	"none": "keine",
+	"secretGenerator (values stay in local files)": "secretGenerator (Werte bleiben in lokalen Dateien)",
+}
@@ -0,0 +1,325 @@
+package prompts
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func TestLocalizedPromptsKeepEnglishMessage(t *testing.T) {
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	p := &survey.Confirm{Message: "Write the files?"}
+	sc := &Script{Answers: map[string]string{"Write the files?": "y"}}
+	var write bool
+	if err := sc.Run(func() error { return askOne(p, &write) }); err != nil {
+		t.Fatal(err)
+	}
+	if !write {
+		t.Error("answer = false, want true")
+	}
+
+	var out bytes.Buffer
+	prevOut, prevStdin, prevPlain := Out, stdin, Plain
+	Out, stdin, Plain = &out, bufio.NewReader(strings.NewReader("n\n")), true
+	defer func() { Out, stdin, Plain = prevOut, prevStdin, prevPlain }()
+	if err := askOne(p, &write); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Dateien schreiben?") {
+		t.Errorf("prompt not shown translated: %q", out.String())
+	}
+	if p.Message != "Write the files?" || p.Help != "" {
+		t.Errorf("asking changed the prompt to %q, help %q", p.Message, p.Help)
+	}
+}
+
+func TestTranslationsFillInParts(t *testing.T) {
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	for _, tc := range []struct{ english, want string }{
+		{"Write the files?", "Dateien schreiben?"},
+		{"prod replicas:", "Replikate für prod:"},
+		{"Public hostnames for dev (optional):", "Hostnamen (Öffentlich) für dev (optional):"},
+		{"Server-side apply overlays/prod to kind-dev?", "overlays/prod serverseitig auf kind-dev anwenden?"},
+		{"Node selector KEY=VALUE for eu (empty to finish)", "Node-Selektor KEY=VALUE für eu (leer zum Beenden)"},
+		{"Unknown message:", "Unknown message:"},
+	} {
+		if got := T(tc.english); got != tc.want {
+			t.Errorf("T(%q) = %q, want %q", tc.english, got, tc.want)
+		}
+	}
+}
+
+func TestLocalizedOptionsAnswerInEnglish(t *testing.T) {
+	if err := SetLocale("de"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	var out bytes.Buffer
+	prevOut, prevStdin, prevPlain := Out, stdin, Plain
+	Out, stdin, Plain = &out, bufio.NewReader(strings.NewReader("2\n\n")), true
+	defer func() { Out, stdin, Plain = prevOut, prevStdin, prevPlain }()
+
+	var exposure string
+	err := askOne(&survey.Select{Message: "Routing backend:", Options: []string{"None", "Private"}, Default: "None"}, &exposure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exposure != "Private" {
+		t.Errorf("select answer = %q, want Private", exposure)
+	}
+	var spread []string
+	err = askOne(&survey.MultiSelect{Message: "Spread pods across:", Options: []string{"Zones", "Nodes"}, Default: []string{"Zones"}}, &spread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spread, []string{"Zones"}) {
+		t.Errorf("multi-select answer = %q, want [Zones]", spread)
+	}
+	for _, shown := range []string{"1) Keine", "2) Privat", "1) Zonen", "2) Knoten"} {
+		if !strings.Contains(out.String(), shown) {
+			t.Errorf("option %q not shown:\n%s", shown, out.String())
+		}
+	}
+}
+
+// promptTexts returns the texts the prompts of the package show: messages,
+// help and the options of selections, with a * for the parts filled in when
+// asking.
+func promptTexts(t *testing.T) []string {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := pkgs["prompts"].Files
+
+	// consts and vars initialized with strings or string lists.
+	values := map[string]ast.Expr{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			d, ok := decl.(*ast.GenDecl)
+			if !ok || (d.Tok != token.CONST && d.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range d.Specs {
+				s := spec.(*ast.ValueSpec)
+				for i, name := range s.Names {
+					if i < len(s.Values) {
+						values[name.Name] = s.Values[i]
+					}
+				}
+			}
+		}
+	}
+	var text func(e ast.Expr) string
+	text = func(e ast.Expr) string {
+		switch e := e.(type) {
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(e.Value); err == nil {
+				return s
+			}
+		case *ast.Ident:
+			if v, ok := values[e.Name]; ok {
+				return text(v)
+			}
+		case *ast.BinaryExpr:
+			if e.Op == token.ADD {
+				return strings.ReplaceAll(text(e.X)+text(e.Y), "**", "*")
+			}
+		case *ast.ParenExpr:
+			return text(e.X)
+		}
+		return "*"
+	}
+	var options func(e ast.Expr) []string
+	options = func(e ast.Expr) []string {
+		switch e := e.(type) {
+		case *ast.Ident:
+			if v, ok := values[e.Name]; ok {
+				return options(v)
+			}
+		case *ast.CompositeLit:
+			var texts []string
+			for _, elt := range e.Elts {
+				texts = append(texts, text(elt))
+			}
+			return texts
+		}
+		return nil
+	}
+
+	seen := map[string]bool{}
+	add := func(s string) {
+		if strings.IndexFunc(strings.ReplaceAll(s, "*", ""), unicode.IsLetter) >= 0 {
+			seen[s] = true
+		}
+	}
+	// appended reports whether body appends the variable v to the options.
+	appended := func(body ast.Node, v string) bool {
+		found := false
+		ast.Inspect(body, func(n ast.Node) bool {
+			if c, ok := n.(*ast.CallExpr); ok && len(c.Args) == 2 {
+				fun, _ := c.Fun.(*ast.Ident)
+				list, _ := c.Args[0].(*ast.Ident)
+				arg, _ := c.Args[1].(*ast.Ident)
+				found = found || fun != nil && fun.Name == "append" && list != nil && list.Name == "options" && arg != nil && arg.Name == v
+			}
+			return !found
+		})
+		return found
+	}
+	for _, elt := range values["questionHelps"].(*ast.CompositeLit).Elts {
+		for _, field := range elt.(*ast.KeyValueExpr).Value.(*ast.CompositeLit).Elts {
+			if kv := field.(*ast.KeyValueExpr); kv.Key.(*ast.Ident).Name == "Text" {
+				add(text(kv.Value))
+			}
+		}
+	}
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CompositeLit:
+				typ := ""
+				switch t := n.Type.(type) {
+				case *ast.SelectorExpr:
+					typ = t.Sel.Name
+				case *ast.Ident:
+					typ = t.Name
+				}
+				for _, elt := range n.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					key, _ := kv.Key.(*ast.Ident)
+					switch {
+					case key == nil:
+					case typ == "Input" || typ == "Password" || typ == "Confirm" || typ == "Select" || typ == "MultiSelect":
+						switch key.Name {
+						case "Message", "Help":
+							add(text(kv.Value))
+						case "Options":
+							for _, o := range options(kv.Value) {
+								add(o)
+							}
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				// Options built up in a local variable.
+				if id, ok := n.Lhs[0].(*ast.Ident); ok && id.Name == "options" {
+					for _, rhs := range n.Rhs {
+						switch rhs := rhs.(type) {
+						case *ast.CompositeLit:
+							for _, o := range options(rhs) {
+								add(o)
+							}
+						case *ast.CallExpr:
+							if fun, ok := rhs.Fun.(*ast.Ident); ok && fun.Name == "append" {
+								for _, arg := range rhs.Args[1:] {
+									add(text(arg))
+								}
+							}
+						}
+					}
+				}
+			case *ast.RangeStmt:
+				// Options listing the keys of a map.
+				key, _ := n.Key.(*ast.Ident)
+				x, _ := n.X.(*ast.Ident)
+				if key != nil && x != nil && appended(n.Body, key.Name) {
+					if m, ok := values[x.Name].(*ast.CompositeLit); ok {
+						for _, elt := range m.Elts {
+							add(text(elt.(*ast.KeyValueExpr).Key))
+						}
+					}
+				}
+			case *ast.CallExpr:
+				name := ""
+				switch f := n.Fun.(type) {
+				case *ast.Ident:
+					name = f.Name
+				case *ast.IndexExpr:
+					if id, ok := f.X.(*ast.Ident); ok {
+						name = id.Name
+					}
+				}
+				switch name {
+				case "askList":
+					add(text(n.Args[0]) + " (empty to finish)")
+					add(text(n.Args[1]))
+				case "keepEntries", "askMore", "T":
+					add(text(n.Args[0]))
+				}
+			case *ast.FuncDecl:
+				if n.Name.Name == "OptIn" {
+					ast.Inspect(n.Body, func(n ast.Node) bool {
+						if r, ok := n.(*ast.ReturnStmt); ok {
+							add(text(r.Results[0]))
+						}
+						return true
+					})
+				}
+			}
+			return true
+		})
+	}
+	texts := make([]string, 0, len(seen))
+	for s := range seen {
+		texts = append(texts, s)
+	}
+	sort.Strings(texts)
+	return texts
+}
+
+// keptEnglish are the options left in English: versions, environments,
+// kinds, field names and values, and the names of tools.
+var keptEnglish = map[string]bool{
+	"1.26.0": true, "1.27.0": true, "1.28.0": true, "1.29.0": true, "1.30.0": true, "master": true,
+	"dev": true, "staging": true, "prod": true,
+	"ACME (Let's Encrypt)": true, "Argo CD": true, "Flux": true, "Gateway API": true, "Ingress (NGINX)": true,
+	"Istio": true, "Sealed Secret (kubeseal)": true, "JSON 6902": true, "Strategic merge": true,
+	"ClusterIssuer": true, "CronJob": true, "Deployment": true, "Issuer": true, "Job": true, "Namespace": true,
+	"PodMonitor": true, "ServiceMonitor": true, "StatefulSet": true, "Workload": true,
+	"Allow": true, "Forbid": true, "Replace": true, "DISABLE": true, "PERMISSIVE": true, "STRICT": true,
+	"DNS": true, "Internet": true, "HTTP GET": true, "Opaque": true, "kubernetes.io/basic-auth": true,
+	"kubernetes.io/dockerconfigjson": true, "kubernetes.io/tls": true, "maxUnavailable": true,
+	"minAvailable": true, "add": true, "remove": true, "replace": true,
+}
+
+func TestGermanCatalogIsComplete(t *testing.T) {
+	texts := map[string]bool{}
+	for _, s := range promptTexts(t) {
+		texts[s] = true
+		if _, ok := catalogs["de"][s]; !ok && !keptEnglish[s] {
+			t.Errorf("no German translation of %q", s)
+		}
+	}
+	for s := range catalogs["de"] {
+		if !texts[s] {
+			t.Errorf("German translation of %q, which no prompt shows", s)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package prompts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Image is an images override.
+type Image struct {
+	Name    string `yaml:"name"`
+	NewName string `yaml:"newName,omitempty"`
+	NewTag  string `yaml:"newTag,omitempty"`
+	Digest  string `yaml:"digest,omitempty"`
+}
+
+var (
+	imageTag    = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+func validateTagOrDigest(ans interface{}) error {
+	s, _ := ans.(string)
+	if imageTag.MatchString(s) || imageDigest.MatchString(s) {
+		return nil
+	}
+	return fmt.Errorf("%q is neither a tag nor a sha256 digest", s)
+}
+
+// splitImage splits an image reference into its name and tag or digest.
+func splitImage(ref string) (name, tag string) {
+	if name, digest, ok := strings.Cut(ref, "@"); ok {
+		return name, digest
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// ReadImages returns the container images used by the resource files,
+// which are relative to dir, without their tags.
+func ReadImages(dir string, resources []string) ([]string, error) {
+	seen := map[string]bool{}
+	var images []string
+	for _, r := range resources {
+		path := filepath.Join(dir, filepath.FromSlash(r))
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		dec := yaml.NewDecoder(f)
+		for {
+			var doc interface{}
+			err := dec.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				f.Close()
+				return nil, fmt.Errorf("%s: %w", r, err)
+			}
+			for _, ref := range findImages(doc) {
+				name, _ := splitImage(ref)
+				if !seen[name] {
+					seen[name] = true
+					images = append(images, name)
+				}
+			}
+		}
+		f.Close()
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+func findImages(node interface{}) []string {
+	var images []string
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, v := range n {
+			if s, ok := v.(string); ok && key == "image" {
+				images = append(images, s)
+				continue
+			}
+			images = append(images, findImages(v)...)
+		}
+	case []interface{}:
+		for _, v := range n {
+			images = append(images, findImages(v)...)
+		}
+	}
+	return images
+}
+
+// ImageOptions asks for image overrides per overlay. Images used by the base
+// resources read from baseDir are offered for selection.
+func ImageOptions(l *Layout, baseDir string) error {
+	known, err := ReadImages(baseDir, l.Base.Resources)
+	if err != nil {
+		return err
+	}
+	for _, env := range l.Envs {
+		overlay := l.Overlays[env]
+		options := append([]string(nil), known...)
+		var current []string
+		existing := map[string]Image{}
+		for _, img := range overlay.Images {
+			existing[img.Name] = img
+			current = append(current, img.Name)
+			if !containsString(options, img.Name) {
+				options = append(options, img.Name)
+			}
+		}
+
+		var names []string
+		if len(options) > 0 {
+			err = askOne(&survey.MultiSelect{
+				Message: "Images to override in " + env + ":",
+				Options: options,
+				Default: current,
+			}, &names)
+			if err != nil {
+				return err
+			}
+		}
+		extra, err := askList("Other image to override in "+env, "The image name as used in the manifests, without tag.", nil)
+		if err != nil {
+			return err
+		}
+		overlay.Images = nil
+		for _, name := range append(names, extra...) {
+			img, err := askImage(name, existing[name])
+			if err != nil {
+				return err
+			}
+			overlay.Images = append(overlay.Images, img)
+		}
+	}
+	return nil
+}
+
+// askImage asks for the override of the named image, defaulting to the
+// current override.
+func askImage(name string, current Image) (Image, error) {
+	img := Image{Name: name}
+	err := askOne(&survey.Input{
+		Message: "New name for " + name + " (optional):",
+		Default: current.NewName,
+		Help:    "Replaces the image name, e.g. to pull from a mirror registry.",
+	}, &img.NewName)
+	if err != nil {
+		return img, err
+	}
+	repo := name
+	if img.NewName != "" {
+		repo = img.NewName
+	}
+
+	var tag string
+	var lookup bool
+	if err := askOne(&survey.Confirm{Message: "Look up tags of " + repo + "?"}, &lookup); err != nil {
+		return img, err
+	}
+	if lookup {
+		tags, err := ListTags(repo)
+		if err != nil {
+			note("Tag lookup failed:", err)
+		} else if len(tags) > 0 {
+			prompt := &survey.Select{Message: "Tag:", Options: tags, PageSize: 15}
+			if latest := latestRelease(tags); latest != "" {
+				prompt.Default = latest
+			}
+			if err := askOne(prompt, &tag); err != nil {
+				return img, err
+			}
+		}
+	}
+	if tag == "" {
+		err := askOne(&survey.Input{Message: "New tag or digest:", Default: current.NewTag + current.Digest}, &tag,
+			survey.WithValidator(survey.Required), survey.WithValidator(validateTagOrDigest))
+		if err != nil {
+			return img, err
+		}
+	}
+	if imageDigest.MatchString(tag) {
+		img.Digest = tag
+	} else {
+		img.NewTag = tag
+	}
+	return img, nil
+}
+
+// registryRepo returns the registry host and repository path of an image
+// name, following the Docker Hub defaults.
+func registryRepo(name string) (string, string) {
+	registry, repo, ok := strings.Cut(name, "/")
+	if !ok || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		registry, repo = "docker.io", name
+	}
+	if registry == "docker.io" {
+		registry = "registry-1.docker.io"
+		if !strings.Contains(repo, "/") {
+			repo = "library/" + repo
+		}
+	}
+	return registry, repo
+}
+
+// ListTags returns the tags of an image from its registry, following the
+// pages the registry splits the list into and using anonymous token auth
+// when the registry asks for it.
+func ListTags(name string) ([]string, error) {
+	registry, repo := registryRepo(name)
+	next := "https://" + registry + "/v2/" + repo + "/tags/list"
+
+	var tags []string
+	var token string
+	for next != "" {
+		resp, err := registryGet(next, &token)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("listing tags of %s: %s", name, resp.Status)
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, list.Tags...)
+		if next, err = nextPage(resp); err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// registryGet gets url, fetching an anonymous token into token when the
+// registry asks for one and sending it on later requests.
+func registryGet(url string, token *string) (*http.Response, error) {
+	get := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if *token != "" {
+			req.Header.Set("Authorization", "Bearer "+*token)
+		}
+		return httpClient.Do(req)
+	}
+	resp, err := get()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if *token, err = anonymousToken(challenge); err != nil {
+		return nil, err
+	}
+	return get()
+}
+
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// nextPage returns the URL of the page after resp from its Link header, or
+// an empty string on the last page.
+func nextPage(resp *http.Response) (string, error) {
+	m := nextLink.FindStringSubmatch(resp.Header.Get("Link"))
+	if m == nil {
+		return "", nil
+	}
+	u, err := resp.Request.URL.Parse(m[1])
+	if err != nil {
+		return "", fmt.Errorf("registry Link header: %w", err)
+	}
+	return u.String(), nil
+}
+
+// latestRelease returns the highest semantic version among tags, ignoring
+// pre-releases, or an empty string when no tag is a release version.
+func latestRelease(tags []string) string {
+	var latest string
+	var latestVersion *version.Version
+	for _, tag := range tags {
+		v, err := version.ParseSemantic(tag)
+		if err != nil || v.PreRelease() != "" {
+			continue
+		}
+		if latestVersion == nil || v.GreaterThan(latestVersion) {
+			latest, latestVersion = tag, v
+		}
+	}
+	return latest
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+func anonymousToken(challenge string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported registry auth %q", challenge)
+	}
+	params := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	req, err := http.NewRequest(http.MethodGet, params["realm"], nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("service", params["service"])
+	q.Set("scope", params["scope"])
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
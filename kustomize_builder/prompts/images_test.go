@@ -0,0 +1,40 @@
+package prompts
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestListTagsFollowsPages(t *testing.T) {
+	pages := map[string]string{
+		"":      `{"tags": ["1.0.0", "1.2.0"]}`,
+		"1.2.0": `{"tags": ["1.10.0", "2.0.0-rc.1"]}`,
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last := r.URL.Query().Get("last")
+		if last == "" {
+			w.Header().Set("Link", `</v2/team/shop/tags/list?n=2&last=1.2.0>; rel="next"`)
+		}
+		fmt.Fprint(w, pages[last])
+	}))
+	defer srv.Close()
+	prev := httpClient
+	httpClient = srv.Client()
+	defer func() { httpClient = prev }()
+
+	tags, err := ListTags(strings.TrimPrefix(srv.URL, "https://") + "/team/shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"1.0.0", "1.2.0", "1.10.0", "2.0.0-rc.1"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("tags = %v, want %v", tags, want)
+	}
+	if latest := latestRelease(tags); latest != "1.10.0" {
+		t.Errorf("latestRelease = %q, want 1.10.0", latest)
+	}
+}
@@ -0,0 +1,127 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// ingressModule routes traffic to the app with standard Ingress resources,
+// e.g. for ingress-nginx on clusters without a service mesh.
+type ingressModule struct{}
+
+func (ingressModule) Name() string { return "Ingress" }
+
+func (ingressModule) Applies(l *Layout) bool { return usesBackend(l, BackendIngress) }
+
+func validateIngressPath(ans interface{}) error {
+	if !strings.HasPrefix(answer(ans), "/") {
+		return fmt.Errorf("path must start with /")
+	}
+	return nil
+}
+
+func (ingressModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:     "publicClass",
+			Prompt:   &survey.Input{Message: "ingressClassName for public traffic:", Default: "nginx"},
+			Validate: ValidateDNSSubdomain,
+		},
+		{
+			Name:     "privateClass",
+			Prompt:   &survey.Input{Message: "ingressClassName for private traffic:", Default: "nginx-internal"},
+			Validate: ValidateDNSSubdomain,
+		},
+		{
+			Name:     "path",
+			Prompt:   &survey.Input{Message: "Path:", Default: "/"},
+			Validate: validateIngressPath,
+		},
+		{
+			Name:     "tlsSecret",
+			Prompt:   &survey.Input{Message: "TLS secret name (optional):", Help: "Secret of type kubernetes.io/tls holding the certificate for the host."},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+	}
+}
+
+func (ingressModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	var files []File
+	for _, exposure := range l.Routing.Exposures() {
+		name := l.App + "-" + strings.ToLower(exposure)
+		spec := ingressSpec{
+			Name:      name,
+			Service:   l.App,
+			Class:     answers.String("publicClass"),
+			Path:      answers.String("path"),
+			TLSSecret: answers.String("tlsSecret"),
+		}
+		if exposure == "Private" {
+			spec.Class = answers.String("privateClass")
+		}
+		if l.Routing.TLSSecret != "" {
+			spec.TLSSecret = l.Routing.TLSSecret
+		}
+		ingress, err := routeFiles(l, "ingress-"+name+".yaml", exposure, func(hosts []string) map[string]interface{} {
+			spec.Hosts = hosts
+			return spec.manifest()
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, ingress...)
+	}
+	return files, nil
+}
+
+type ingressSpec struct {
+	Name      string
+	Service   string
+	Class     string
+	Hosts     []string
+	Path      string
+	TLSSecret string
+}
+
+func (s ingressSpec) manifest() map[string]interface{} {
+	metadata := map[string]interface{}{"name": s.Name}
+	var rules []interface{}
+	for _, host := range s.Hosts {
+		rules = append(rules, map[string]interface{}{
+			"host": host,
+			"http": map[string]interface{}{
+				"paths": []interface{}{
+					map[string]interface{}{
+						"path":     s.Path,
+						"pathType": "Prefix",
+						"backend": map[string]interface{}{
+							"service": map[string]interface{}{
+								"name": s.Service,
+								"port": map[string]interface{}{"number": 80},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	spec := map[string]interface{}{
+		"ingressClassName": s.Class,
+		"rules":            rules,
+	}
+	if s.TLSSecret != "" {
+		spec["tls"] = []interface{}{
+			map[string]interface{}{"hosts": s.Hosts, "secretName": s.TLSSecret},
+		}
+		metadata["annotations"] = map[string]interface{}{"nginx.ingress.kubernetes.io/ssl-redirect": "true"}
+	}
+	return map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   metadata,
+		"spec":       spec,
+	}
+}
@@ -0,0 +1,369 @@
+package prompts
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Istio gateways VirtualServices are bound to, by exposure.
+const (
+	PublicGateway  = "istio-system/public-gateway"
+	PrivateGateway = "istio-system/private-gateway"
+)
+
+// istioModule routes traffic to the app through the public and/or private
+// Istio ingress gateways.
+type istioModule struct{}
+
+func (istioModule) Name() string { return "Istio" }
+
+func (istioModule) Applies(l *Layout) bool { return usesBackend(l, BackendIstio) }
+
+func (istioModule) Questions() []*survey.Question { return nil }
+
+func (istioModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	var files []File
+	for _, exposure := range l.Routing.Exposures() {
+		gateway, name, selector := PublicGateway, l.App+"-public", "ingressgateway"
+		if exposure == "Private" {
+			gateway, name, selector = PrivateGateway, l.App+"-private", "internal-ingressgateway"
+		}
+		if l.Routing.TLSSecret != "" {
+			// The shared gateways cannot read the app's secret, so TLS is
+			// terminated by a gateway of the app's own.
+			gateway = name
+			gw, err := routeFiles(l, "gateway-"+name+".yaml", exposure, func(hosts []string) map[string]interface{} {
+				return istioGateway(name, selector, hosts, l.Routing.TLSSecret)
+			})
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, gw...)
+		}
+		vs, err := routeFiles(l, "virtualservice-"+name+".yaml", exposure, func(hosts []string) map[string]interface{} {
+			return virtualService(name, l.App, hosts, gateway, l.Routing.Subsets)
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, vs...)
+	}
+	return files, nil
+}
+
+func istioGateway(name, selector string, hosts []string, tlsSecret string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "Gateway",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"istio": selector},
+			"servers": []interface{}{
+				map[string]interface{}{
+					"hosts": hosts,
+					"port":  map[string]interface{}{"number": 443, "name": "https", "protocol": "HTTPS"},
+					"tls":   map[string]interface{}{"mode": "SIMPLE", "credentialName": tlsSecret},
+				},
+			},
+		},
+	}
+}
+
+// virtualService routes hosts through gateway to the app, split between
+// subsets by weight when there are any.
+func virtualService(name, app string, hosts []string, gateway string, subsets []Subset) map[string]interface{} {
+	destination := func(subset string) map[string]interface{} {
+		d := map[string]interface{}{
+			"host": app,
+			"port": map[string]interface{}{"number": 80},
+		}
+		if subset != "" {
+			d["subset"] = subset
+		}
+		return d
+	}
+	route := []interface{}{map[string]interface{}{"destination": destination("")}}
+	if len(subsets) > 0 {
+		route = nil
+		for _, s := range subsets {
+			route = append(route, map[string]interface{}{"destination": destination(s.Name), "weight": s.Weight})
+		}
+	}
+	return map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1beta1",
+		"kind":       "VirtualService",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"hosts":    hosts,
+			"gateways": []string{gateway},
+			"http":     []interface{}{map[string]interface{}{"route": route}},
+		},
+	}
+}
+
+// Service accounts of the Istio ingress gateways, by exposure.
+var gatewayPrincipals = map[string]string{
+	"Public":  "cluster.local/ns/istio-system/sa/istio-ingressgateway-service-account",
+	"Private": "cluster.local/ns/istio-system/sa/istio-internal-ingressgateway-service-account",
+}
+
+// istioAuthzModule denies all requests to the app by default and allows
+// those of the given sources and paths.
+type istioAuthzModule struct{}
+
+func (istioAuthzModule) Name() string { return "Istio authorization" }
+
+func (istioAuthzModule) Applies(l *Layout) bool { return usesBackend(l, BackendIstio) }
+
+func (istioAuthzModule) OptIn() string {
+	return "Generate AuthorizationPolicies denying all but explicitly allowed requests?"
+}
+
+func (istioAuthzModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:   "gateways",
+			Prompt: &survey.Confirm{Message: "Allow requests through the ingress gateways?", Default: true},
+		},
+		{
+			Name: "principals",
+			Prompt: &survey.Input{
+				Message: "Allowed principals, comma separated (optional):",
+				Help:    "Workload identities such as cluster.local/ns/shop/sa/frontend.",
+			},
+		},
+		{
+			Name:     "namespaces",
+			Prompt:   &survey.Input{Message: "Allowed source namespaces, comma separated (optional):"},
+			Validate: listOf(ValidateDNSLabel),
+		},
+		{
+			Name: "paths",
+			Prompt: &survey.Input{
+				Message: "Paths they may request, comma separated (optional):",
+				Help:    "Exact paths, or prefixes such as /api/*. Empty allows all paths.",
+			},
+			Validate: listOf(validateIngressPath),
+		},
+	}
+}
+
+func (istioAuthzModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	selector := map[string]interface{}{"matchLabels": appLabels(l.App)}
+
+	var rules []interface{}
+	if answers.Bool("gateways") {
+		var principals []string
+		for _, exposure := range l.Routing.Exposures() {
+			principals = append(principals, gatewayPrincipals[exposure])
+		}
+		rules = append(rules, map[string]interface{}{
+			"from": []interface{}{map[string]interface{}{"source": map[string]interface{}{"principals": principals}}},
+		})
+	}
+	source := map[string]interface{}{}
+	if principals := splitList(answers.String("principals")); len(principals) > 0 {
+		source["principals"] = principals
+	}
+	if namespaces := splitList(answers.String("namespaces")); len(namespaces) > 0 {
+		source["namespaces"] = namespaces
+	}
+	paths := splitList(answers.String("paths"))
+	if len(source) > 0 || len(paths) > 0 {
+		rule := map[string]interface{}{}
+		if len(source) > 0 {
+			rule["from"] = []interface{}{map[string]interface{}{"source": source}}
+		}
+		if len(paths) > 0 {
+			rule["to"] = []interface{}{map[string]interface{}{"operation": map[string]interface{}{"paths": paths}}}
+		}
+		rules = append(rules, rule)
+	}
+
+	var files []File
+	add := func(file string, obj map[string]interface{}) error {
+		content, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, file), Content: content})
+		l.Base.AddResource(file)
+		return nil
+	}
+	// An ALLOW policy without rules matches nothing, denying all requests
+	// not allowed by another policy.
+	err := add("authorizationpolicy-deny-all.yaml", authorizationPolicy(l.App+"-deny-all", map[string]interface{}{"selector": selector}))
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		err := add("authorizationpolicy-"+l.App+".yaml", authorizationPolicy(l.App, map[string]interface{}{
+			"selector": selector,
+			"action":   "ALLOW",
+			"rules":    rules,
+		}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func authorizationPolicy(name string, spec map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "security.istio.io/v1",
+		"kind":       "AuthorizationPolicy",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       spec,
+	}
+}
+
+// mTLS modes of PeerAuthentications.
+var mtlsModes = []string{"STRICT", "PERMISSIVE", "DISABLE"}
+
+// peerAuthModule sets the mesh mTLS mode of the app's namespace or workload
+// per environment.
+type peerAuthModule struct{}
+
+func (peerAuthModule) Name() string { return "mTLS" }
+
+func (peerAuthModule) OptIn() string { return "Set the Istio mTLS mode per environment?" }
+
+func (peerAuthModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "scope",
+			Prompt: &survey.Select{
+				Message: "Apply the mode to:",
+				Options: []string{"Workload", "Namespace"},
+				Help:    "Namespace applies it to every workload in the overlay's namespace.",
+			},
+		},
+	}
+}
+
+func (peerAuthModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	name, spec := l.App, map[string]interface{}{"selector": map[string]interface{}{"matchLabels": appLabels(l.App)}}
+	if answers.String("scope") == "Namespace" {
+		// A PeerAuthentication named default applies to the whole namespace.
+		name, spec = "default", map[string]interface{}{}
+	}
+
+	var files []File
+	for _, env := range l.Envs {
+		mode := "PERMISSIVE"
+		if strings.HasPrefix(env, "prod") {
+			mode = "STRICT"
+		}
+		err := askOne(&survey.Select{Message: "mTLS mode for " + env + ":", Options: mtlsModes, Default: mode}, &mode)
+		if err != nil {
+			return nil, err
+		}
+		spec["mtls"] = map[string]interface{}{"mode": mode}
+		content, err := marshalYAML(map[string]interface{}{
+			"apiVersion": "security.istio.io/v1",
+			"kind":       "PeerAuthentication",
+			"metadata":   map[string]interface{}{"name": name},
+			"spec":       spec,
+		})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(OverlayDir(env), "peerauthentication.yaml"), Content: content})
+		l.Overlays[env].AddResource("peerauthentication.yaml")
+	}
+	return files, nil
+}
+
+// sidecarModule enables or disables Istio sidecar injection for the app's
+// namespace or pods, optionally pinning an Istio revision per environment.
+type sidecarModule struct{}
+
+func (sidecarModule) Name() string { return "Sidecar injection" }
+
+func (sidecarModule) OptIn() string { return "Configure Istio sidecar injection?" }
+
+func (sidecarModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:   "inject",
+			Prompt: &survey.Confirm{Message: "Inject sidecars?", Default: true},
+		},
+		{
+			Name: "scope",
+			Prompt: &survey.Select{
+				Message: "Label the:",
+				Options: []string{"Namespace", "Workload"},
+				Help:    "Namespace labels need the Namespace to be one of the base resources.",
+			},
+		},
+	}
+}
+
+func (sidecarModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	inject := answers.Bool("inject")
+	namespace := answers.String("scope") == "Namespace"
+	if namespace && !containsString(l.Base.Resources, "namespace.yaml") {
+		note("Note: the base has no namespace.yaml, so the injection labels only apply once it does.")
+	}
+
+	var files []File
+	for _, env := range l.Envs {
+		var revision string
+		if inject {
+			err := askOne(&survey.Input{
+				Message: "Istio revision for " + env + " (optional):",
+				Help:    "E.g. 1-22 or a revision tag such as stable. Empty uses the default revision.",
+			}, &revision, survey.WithValidator(Optional(ValidateLabelValue)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var labels map[string]interface{}
+		var kind, apiVersion string
+		spec := map[string]interface{}{}
+		if namespace {
+			kind, apiVersion = "Namespace", "v1"
+			// istio-injection takes precedence over istio.io/rev, so it is
+			// removed when a revision is set.
+			labels = map[string]interface{}{"istio-injection": "disabled"}
+			if inject {
+				labels["istio-injection"] = "enabled"
+				if revision != "" {
+					labels = map[string]interface{}{"istio-injection": nil, "istio.io/rev": revision}
+				}
+			}
+		} else {
+			kind, apiVersion = "Deployment", "apps/v1"
+			podLabels := map[string]interface{}{"sidecar.istio.io/inject": strconv.FormatBool(inject)}
+			if revision != "" {
+				podLabels["istio.io/rev"] = revision
+			}
+			spec["template"] = map[string]interface{}{"metadata": map[string]interface{}{"labels": podLabels}}
+		}
+		metadata := map[string]interface{}{"name": "not-used"}
+		if labels != nil {
+			metadata["labels"] = labels
+		}
+		patch := map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}
+		if len(spec) > 0 {
+			patch["spec"] = spec
+		}
+		content, err := marshalYAML(patch)
+		if err != nil {
+			return nil, err
+		}
+		file := "patches/sidecar-injection.yaml"
+		files = append(files, File{Path: path.Join(OverlayDir(env), file), Content: content})
+		l.Overlays[env].AddPatch(Patch{Path: file, Target: &Selector{Kind: kind}})
+	}
+	return files, nil
+}
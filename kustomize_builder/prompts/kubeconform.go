@@ -0,0 +1,157 @@
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"golang.org/x/term"
+)
+
+// KubernetesVersions are offered when selecting the version to validate
+// against.
+var KubernetesVersions = []string{"master", "1.30.0", "1.29.0", "1.28.0", "1.27.0", "1.26.0"}
+
+// crdSchemas is where kubeconform looks up the schemas of custom resources,
+// such as the Istio, Gateway API, cert-manager and Prometheus Operator ones
+// the modules generate.
+const crdSchemas = "https://raw.githubusercontent.com/datreeio/CRDs-catalog/main/{{.Group}}/{{.ResourceKind}}_{{.ResourceAPIVersion}}.json"
+
+// ValidationError is a manifest kubeconform rejected.
+type ValidationError struct {
+	Filename string `json:"filename"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Msg      string `json:"msg"`
+}
+
+func (e ValidationError) Error() string {
+	if e.Kind == "" {
+		return fmt.Sprintf("%s: %s", e.Filename, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", e.Filename, e.Kind, e.Name, e.Msg)
+}
+
+// Interactive reports whether prompts can be answered on stdin, or as the
+// running script says.
+func Interactive() bool {
+	if script != nil {
+		return script.Interactive
+	}
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// KubernetesVersionPrompt asks which Kubernetes version to validate against.
+func KubernetesVersionPrompt() (string, error) {
+	version := KubernetesVersions[0]
+	err := askOne(&survey.Select{
+		Message: "Validate manifests against Kubernetes version:",
+		Options: KubernetesVersions,
+		Default: version,
+	}, &version)
+	return version, err
+}
+
+// Kubeconform validates the generated files the layout's kustomizations list
+// as resources against the schemas of the given Kubernetes version, and
+// custom resources against the CRDs catalog. Patches and the kustomizations
+// themselves are not complete objects and are skipped.
+func Kubeconform(l *Layout, version string) ([]ValidationError, error) {
+	dir, err := os.MkdirTemp("", "kustomize-builder-validate")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	listed := map[string]bool{}
+	for dir, k := range l.Kustomizations() {
+		for _, r := range k.Resources {
+			listed[path.Join(dir, r)] = true
+		}
+	}
+	var selected []File
+	for _, f := range l.Files {
+		if listed[f.Path] {
+			selected = append(selected, f)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, nil
+	}
+	if err := WriteFiles(dir, selected); err != nil {
+		return nil, err
+	}
+
+	// Custom resources without a schema in the catalog are skipped rather
+	// than failed.
+	args := []string{
+		"-strict", "-output", "json", "-kubernetes-version", version,
+		"-schema-location", "default", "-schema-location", crdSchemas, "-ignore-missing-schemas",
+	}
+	for _, f := range selected {
+		args = append(args, filepath.Join(dir, f.Path))
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("kubeconform", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	var result struct {
+		Resources []ValidationError `json:"resources"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("kubeconform: %w: %s", runErr, stderr.String())
+		}
+		return nil, fmt.Errorf("kubeconform: %w", err)
+	}
+
+	var failed []ValidationError
+	for _, r := range result.Resources {
+		if r.Status == "statusValid" || r.Status == "statusSkipped" {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, r.Filename); err == nil {
+			r.Filename = filepath.ToSlash(rel)
+		}
+		failed = append(failed, r)
+	}
+	return failed, nil
+}
+
+// ValidateOptions runs kubeconform over the generated files and prints any
+// errors. Interactively the user may continue anyway, and it reports whether
+// they did; otherwise invalid manifests are an error.
+func ValidateOptions(l *Layout, version string) (bool, error) {
+	failed, err := Kubeconform(l, version)
+	var notFound *exec.Error
+	if errors.As(err, &notFound) {
+		fmt.Fprintln(Out, "kubeconform not found in PATH, skipping validation")
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(failed) == 0 {
+		return true, nil
+	}
+
+	fmt.Fprintln(Out, "Validation errors:")
+	for _, e := range failed {
+		fmt.Fprintln(Out, "- "+e.Error())
+	}
+	if !Interactive() {
+		return false, fmt.Errorf("%d manifests failed validation against Kubernetes %s", len(failed), version)
+	}
+	var proceed bool
+	err = askOne(&survey.Confirm{Message: "Write the files anyway?"}, &proceed)
+	return proceed, err
+}
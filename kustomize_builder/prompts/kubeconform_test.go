@@ -0,0 +1,66 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeKubeconform reports the custom resources of the files it is given as
+// lacking a schema, as kubeconform does when the CRDs catalog is out of
+// reach, and the other resources as valid.
+const fakeKubeconform = `#!/bin/sh
+ignore=
+for a in "$@"; do [ "$a" = -ignore-missing-schemas ] && ignore=1; done
+printf '{"resources": ['
+sep=
+for a in "$@"; do
+	case "$a" in *.yaml) ;; *) continue ;; esac
+	status=statusValid
+	if grep -qE '^apiVersion: .*(istio\.io|gateway\.networking\.k8s\.io|cert-manager\.io|monitoring\.coreos\.com)/' "$a"; then
+		status=statusError
+		[ -n "$ignore" ] && status=statusSkipped
+	fi
+	printf '%s{"filename": "%s", "status": "%s", "msg": "could not find schema"}' "$sep" "$a" "$status"
+	sep=,
+done
+printf ']}\n'
+`
+
+func TestKubeconformSkipsCustomResourcesWithoutSchemas(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "kubeconform"), []byte(fakeKubeconform), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var steps []Step
+	for _, m := range Modules() {
+		steps = append(steps, ModuleStep(m))
+	}
+	for _, run := range goldenRuns {
+		switch run.name {
+		case "istio", "gatewayapi", "monitoring":
+		default:
+			continue
+		}
+		t.Run(run.name, func(t *testing.T) {
+			s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev", "prod"})}
+			sc := &Script{Answers: run.answers, Defaults: true}
+			if _, err := sc.Wizard(s, steps); err != nil {
+				t.Fatalf("%v\n%s", err, sc.Transcript.String())
+			}
+			failed, err := Kubeconform(s.Layout, KubernetesVersions[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range failed {
+				t.Errorf("failed validation: %v", e)
+			}
+			proceed, err := ValidateOptions(s.Layout, KubernetesVersions[0])
+			if err != nil || !proceed {
+				t.Errorf("ValidateOptions = %v, %v, want to proceed", proceed, err)
+			}
+		})
+	}
+}
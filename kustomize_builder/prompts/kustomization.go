@@ -0,0 +1,267 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// Kustomization is the subset of kustomization.yaml the builder knows how to
+// generate. Bases, CommonLabels and the PatchesStrategicMerge and
+// PatchesJSON6902 fields are only generated for the kustomize versions
+// without their newer forms.
+type Kustomization struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Namespace  string   `yaml:"namespace,omitempty"`
+	NamePrefix string   `yaml:"namePrefix,omitempty"`
+	NameSuffix string   `yaml:"nameSuffix,omitempty"`
+	Resources  []string `yaml:"resources,omitempty"`
+	Bases      []string `yaml:"bases,omitempty"`
+	Components []string `yaml:"components,omitempty"`
+
+	Labels            []Label           `yaml:"labels,omitempty"`
+	CommonLabels      map[string]string `yaml:"commonLabels,omitempty"`
+	CommonAnnotations map[string]string `yaml:"commonAnnotations,omitempty"`
+
+	ConfigMapGenerator []ConfigMapArgs   `yaml:"configMapGenerator,omitempty"`
+	SecretGenerator    []SecretArgs      `yaml:"secretGenerator,omitempty"`
+	GeneratorOptions   *GeneratorOptions `yaml:"generatorOptions,omitempty"`
+
+	Patches               []Patch         `yaml:"patches,omitempty"`
+	PatchesStrategicMerge []string        `yaml:"patchesStrategicMerge,omitempty"`
+	PatchesJSON6902       []JSON6902Patch `yaml:"patchesJson6902,omitempty"`
+	Replacements          []Replacement   `yaml:"replacements,omitempty"`
+
+	HelmCharts []HelmChart `yaml:"helmCharts,omitempty"`
+
+	Images []Image `yaml:"images,omitempty"`
+}
+
+func NewKustomization() *Kustomization {
+	return &Kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+	}
+}
+
+// Clone returns a deep copy of k.
+func (k *Kustomization) Clone() *Kustomization {
+	c := *k
+	c.Resources = append([]string(nil), k.Resources...)
+	c.Bases = append([]string(nil), k.Bases...)
+	c.Components = append([]string(nil), k.Components...)
+	c.CommonLabels = cloneMap(k.CommonLabels)
+	c.CommonAnnotations = cloneMap(k.CommonAnnotations)
+	c.PatchesStrategicMerge = append([]string(nil), k.PatchesStrategicMerge...)
+
+	c.Labels = append([]Label(nil), k.Labels...)
+	for i := range c.Labels {
+		c.Labels[i].Pairs = cloneMap(c.Labels[i].Pairs)
+	}
+	c.ConfigMapGenerator = append([]ConfigMapArgs(nil), k.ConfigMapGenerator...)
+	for i, g := range c.ConfigMapGenerator {
+		c.ConfigMapGenerator[i].Literals = append([]string(nil), g.Literals...)
+		c.ConfigMapGenerator[i].Envs = append([]string(nil), g.Envs...)
+		c.ConfigMapGenerator[i].Files = append([]string(nil), g.Files...)
+	}
+	c.SecretGenerator = append([]SecretArgs(nil), k.SecretGenerator...)
+	for i, g := range c.SecretGenerator {
+		c.SecretGenerator[i].Literals = append([]string(nil), g.Literals...)
+		c.SecretGenerator[i].Envs = append([]string(nil), g.Envs...)
+		c.SecretGenerator[i].Files = append([]string(nil), g.Files...)
+	}
+	if k.GeneratorOptions != nil {
+		o := *k.GeneratorOptions
+		o.Labels = cloneMap(o.Labels)
+		o.Annotations = cloneMap(o.Annotations)
+		c.GeneratorOptions = &o
+	}
+
+	c.Patches = append([]Patch(nil), k.Patches...)
+	for i := range c.Patches {
+		c.Patches[i].Target = c.Patches[i].Target.clone()
+	}
+	c.PatchesJSON6902 = append([]JSON6902Patch(nil), k.PatchesJSON6902...)
+	for i := range c.PatchesJSON6902 {
+		c.PatchesJSON6902[i].Target = c.PatchesJSON6902[i].Target.clone()
+	}
+	c.Replacements = append([]Replacement(nil), k.Replacements...)
+	for i, r := range c.Replacements {
+		if r.Source != nil {
+			source := *r.Source
+			c.Replacements[i].Source = &source
+		}
+		c.Replacements[i].Targets = append([]ReplacementTarget(nil), r.Targets...)
+		for j, t := range c.Replacements[i].Targets {
+			t.Select = t.Select.clone()
+			t.FieldPaths = append([]string(nil), t.FieldPaths...)
+			if t.Options != nil {
+				options := *t.Options
+				t.Options = &options
+			}
+			c.Replacements[i].Targets[j] = t
+		}
+	}
+
+	c.HelmCharts = append([]HelmChart(nil), k.HelmCharts...)
+	c.Images = append([]Image(nil), k.Images...)
+	return &c
+}
+
+// cloneMap returns a copy of m, nil when m is.
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// AddResource appends path to resources unless it is already listed.
+func (k *Kustomization) AddResource(path string) {
+	for _, r := range k.Resources {
+		if r == path {
+			return
+		}
+	}
+	k.Resources = append(k.Resources, path)
+}
+
+// RemoveResource removes path from resources.
+func (k *Kustomization) RemoveResource(path string) {
+	for i, r := range k.Resources {
+		if r == path {
+			k.Resources = append(k.Resources[:i], k.Resources[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddPatch appends p unless a patch with the same path is already listed.
+func (k *Kustomization) AddPatch(p Patch) {
+	for _, existing := range k.Patches {
+		if p.Path != "" && existing.Path == p.Path {
+			return
+		}
+	}
+	k.Patches = append(k.Patches, p)
+}
+
+// File is a generated file, relative to the output directory.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+func (k *Kustomization) File() (File, error) {
+	content, err := marshalYAML(k)
+	if err != nil {
+		return File{}, err
+	}
+	return File{Path: "kustomization.yaml", Content: content}, nil
+}
+
+func marshalYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFiles writes files below dir, creating directories as needed. Files
+// whose content did not change are left alone. The files are first written
+// to a staging directory next to dir and then moved into place; if any of
+// that fails, the files and directories are restored to how they were.
+func WriteFiles(dir string, files []File) (err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	tx := &transaction{}
+	defer func() {
+		if err != nil {
+			if rerr := tx.rollback(); rerr != nil {
+				err = fmt.Errorf("%w; rolling back: %v", err, rerr)
+			}
+			return
+		}
+		os.RemoveAll(tx.staging)
+	}()
+	if err := tx.stage(dir); err != nil {
+		return err
+	}
+	if err := tx.mkdirAll(dir); err != nil {
+		return err
+	}
+
+	// staged maps the paths of changed files to their staged copies.
+	staged := map[string]string{}
+	var changed []string
+	for i, f := range files {
+		if existing, err := os.ReadFile(filepath.Join(dir, f.Path)); err == nil && bytes.Equal(existing, f.Content) {
+			continue
+		}
+		staged[f.Path] = filepath.Join(tx.staging, strconv.Itoa(i))
+		if err := os.WriteFile(staged[f.Path], f.Content, 0o644); err != nil {
+			return fmt.Errorf("staging %s: %w", f.Path, err)
+		}
+		changed = append(changed, f.Path)
+	}
+
+	for _, path := range changed {
+		if err := tx.replace(staged[path], filepath.Join(dir, path)); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// PlanFiles writes to w what WriteFiles would do: the files it would create
+// with their contents, and a unified diff of those it would modify.
+func PlanFiles(w io.Writer, dir string, files []File) error {
+	var changed int
+	for _, f := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, f.Path))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil && bytes.Equal(existing, f.Content) {
+			continue
+		}
+		changed++
+		diff := difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(string(f.Content)),
+			FromFile: "a/" + f.Path,
+			ToFile:   "b/" + f.Path,
+			Context:  3,
+		}
+		if err != nil {
+			fmt.Fprintln(w, "create", f.Path)
+			diff.A, diff.FromFile = nil, "/dev/null"
+		} else {
+			fmt.Fprintln(w, "modify", f.Path)
+		}
+		if err := difflib.WriteUnifiedDiff(w, diff); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "%d of %d files would be written to %s\n", changed, len(files), dir)
+	return nil
+}
@@ -0,0 +1,142 @@
+package prompts
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Label is an entry of the labels field, setting pairs on all resources and
+// optionally on selectors and pod templates.
+type Label struct {
+	Pairs            map[string]string `yaml:"pairs"`
+	IncludeSelectors bool              `yaml:"includeSelectors,omitempty"`
+	IncludeTemplates bool              `yaml:"includeTemplates,omitempty"`
+}
+
+func validateAnnotation(ans interface{}) error {
+	key, _, ok := strings.Cut(answer(ans), "=")
+	if !ok {
+		return fmt.Errorf("annotation must be of the form KEY=VALUE")
+	}
+	return ValidateLabelKey(key)
+}
+
+// suggestedLabels returns the recommended app.kubernetes.io labels for the
+// app, part-of being the name of the git repository outDir is in.
+func suggestedLabels(app, outDir string) []string {
+	labels := []string{"app.kubernetes.io/name=" + app}
+	if root := gitOutput(outDir, "rev-parse", "--show-toplevel"); root != "" {
+		if repo := filepath.Base(root); ValidateLabelValue(repo) == nil {
+			labels = append(labels, "app.kubernetes.io/part-of="+repo)
+		}
+	}
+	return append(labels, "app.kubernetes.io/managed-by=kustomize")
+}
+
+func pairs(m map[string]string) []string {
+	var list []string
+	for key, value := range m {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// LabelOptions asks for the labels and annotations set on all resources of
+// the base, defaulting to the current ones.
+func LabelOptions(l *Layout, outDir string) error {
+	k := l.Base
+	current := Label{IncludeTemplates: true}
+	if len(k.Labels) > 0 {
+		current = k.Labels[0]
+	}
+
+	selected := pairs(current.Pairs)
+	options := append([]string(nil), selected...)
+	for _, label := range suggestedLabels(l.App, outDir) {
+		if !containsString(options, label) {
+			options = append(options, label)
+			if len(k.Labels) == 0 {
+				selected = append(selected, label)
+			}
+		}
+	}
+	err := askOne(&survey.MultiSelect{
+		Message: "Common labels:",
+		Options: options,
+		Default: selected,
+	}, &selected)
+	if err != nil {
+		return err
+	}
+	var team string
+	err = askOne(&survey.Input{
+		Message: "Owning team (optional):",
+		Default: current.Pairs["team"],
+		Help:    "Sets the team label.",
+	}, &team, survey.WithValidator(Optional(ValidateLabelValue)))
+	if err != nil {
+		return err
+	}
+	extra, err := askList("Other common label KEY=VALUE", "", ValidateLabel)
+	if err != nil {
+		return err
+	}
+
+	label := Label{Pairs: map[string]string{}}
+	for _, pair := range append(selected, extra...) {
+		key, value, _ := strings.Cut(pair, "=")
+		label.Pairs[key] = value
+	}
+	delete(label.Pairs, "team")
+	if team != "" {
+		label.Pairs["team"] = team
+	}
+	k.Labels = nil
+	if len(label.Pairs) > 0 {
+		qs := []*survey.Question{
+			{
+				Name: "includeTemplates",
+				Prompt: &survey.Confirm{
+					Message: "Add the labels to pod templates?",
+					Default: current.IncludeTemplates,
+					Help:    "Lets pods, and metrics and logs taken from them, carry the labels.",
+				},
+			},
+			{
+				Name: "includeSelectors",
+				Prompt: &survey.Confirm{
+					Message: "Add the labels to selectors?",
+					Default: current.IncludeSelectors,
+					Help:    "Selectors of Deployments cannot change once created, so only enable this for new apps.",
+				},
+			},
+		}
+		if err := ask(qs, &label); err != nil {
+			return err
+		}
+		k.Labels = []Label{label}
+	}
+
+	keep, err := keepEntries("Keep common annotations:", pairs(k.CommonAnnotations), func(s string) string { return s })
+	if err != nil {
+		return err
+	}
+	added, err := askList("Common annotation KEY=VALUE", "", validateAnnotation)
+	if err != nil {
+		return err
+	}
+	k.CommonAnnotations = nil
+	for _, pair := range append(keep, added...) {
+		if k.CommonAnnotations == nil {
+			k.CommonAnnotations = map[string]string{}
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		k.CommonAnnotations[key] = value
+	}
+	return nil
+}
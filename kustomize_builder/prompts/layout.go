@@ -0,0 +1,264 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// BaseDir is the directory of the base kustomization.
+const BaseDir = "base"
+
+// OverlayDir returns the directory of the overlay for env.
+func OverlayDir(env string) string {
+	return path.Join("overlays", env)
+}
+
+// ClusterDir returns the directory of the overlay of env for a cluster.
+func ClusterDir(cluster, env string) string {
+	return path.Join("clusters", cluster, env)
+}
+
+// ComponentDir returns the directory of the named component.
+func ComponentDir(name string) string {
+	return path.Join("components", name)
+}
+
+// Layout is the generated kustomize tree: a base, one overlay per environment,
+// the components the overlays use and the overlays of target clusters.
+type Layout struct {
+	App        string
+	Routing    Routing
+	Workload   Workload
+	Base       *Kustomization
+	Envs       []string
+	Overlays   map[string]*Kustomization
+	Components map[string]*Kustomization
+
+	// Kustomize is the name of the kustomize version the kustomizations
+	// are rendered for, the latest when empty.
+	Kustomize string
+
+	// Clusters are the target clusters, and ClusterOverlays their overlays
+	// of the environment overlays, by directory.
+	Clusters        []Cluster
+	ClusterOverlays map[string]*Kustomization
+
+	// Files are the generated files other than the kustomizations, relative
+	// to the output directory.
+	Files []File
+
+	// Existing holds the kustomization.yaml contents read from disk by
+	// directory, when editing an existing tree.
+	Existing map[string][]byte
+	// Keep holds the directories whose files are left as they are on disk,
+	// such as existing overlays not chosen for modification.
+	Keep map[string]bool
+}
+
+func NewLayout(app string, envs []string) *Layout {
+	l := &Layout{
+		App:             app,
+		Base:            NewKustomization(),
+		Overlays:        map[string]*Kustomization{},
+		Components:      map[string]*Kustomization{},
+		ClusterOverlays: map[string]*Kustomization{},
+	}
+	l.SetEnvs(envs)
+	return l
+}
+
+// SetEnvs sets the environments, adding an overlay for new ones and dropping
+// the overlays of environments no longer listed.
+func (l *Layout) SetEnvs(envs []string) {
+	overlays := map[string]*Kustomization{}
+	for _, env := range envs {
+		o, ok := l.Overlays[env]
+		if !ok {
+			o = NewKustomization()
+			o.AddResource("../../" + BaseDir)
+		}
+		overlays[env] = o
+	}
+	l.Envs = envs
+	l.Overlays = overlays
+}
+
+// Clone returns a deep copy of l.
+func (l *Layout) Clone() *Layout {
+	c := &Layout{
+		App:        l.App,
+		Routing:    l.Routing,
+		Workload:   l.Workload,
+		Kustomize:  l.Kustomize,
+		Base:       l.Base.Clone(),
+		Envs:       append([]string(nil), l.Envs...),
+		Overlays:   map[string]*Kustomization{},
+		Components: map[string]*Kustomization{},
+		Files:      append([]File(nil), l.Files...),
+		Existing:   cloneMap(l.Existing),
+		Keep:       cloneMap(l.Keep),
+
+		Clusters:        append([]Cluster(nil), l.Clusters...),
+		ClusterOverlays: map[string]*Kustomization{},
+	}
+	c.Routing.Hosts = cloneHosts(l.Routing.Hosts)
+	c.Routing.EnvHosts = cloneMap(l.Routing.EnvHosts)
+	for env, hosts := range c.Routing.EnvHosts {
+		c.Routing.EnvHosts[env] = cloneHosts(hosts)
+	}
+	c.Routing.Subsets = append([]Subset(nil), l.Routing.Subsets...)
+	c.Routing.routes = append([]route(nil), l.Routing.routes...)
+	c.Workload.Env = append([]string(nil), l.Workload.Env...)
+	for i := range c.Clusters {
+		c.Clusters[i].Envs = append([]string(nil), c.Clusters[i].Envs...)
+		c.Clusters[i].NodeSelector = cloneMap(c.Clusters[i].NodeSelector)
+	}
+	for dir, content := range c.Existing {
+		c.Existing[dir] = append([]byte(nil), content...)
+	}
+	for env, k := range l.Overlays {
+		c.Overlays[env] = k.Clone()
+	}
+	for name, k := range l.Components {
+		c.Components[name] = k.Clone()
+	}
+	for dir, k := range l.ClusterOverlays {
+		c.ClusterOverlays[dir] = k.Clone()
+	}
+	return c
+}
+
+// cloneHosts returns a deep copy of hostnames by exposure.
+func cloneHosts(hosts map[string][]string) map[string][]string {
+	c := cloneMap(hosts)
+	for exposure, h := range c {
+		c[exposure] = append([]string(nil), h...)
+	}
+	return c
+}
+
+// Generated returns the generated files below dir, relative to it. They
+// are not on disk until the layout is written.
+func (l *Layout) Generated(dir string) []File {
+	var files []File
+	for _, f := range l.Files {
+		if rel := strings.TrimPrefix(f.Path, dir+"/"); rel != f.Path {
+			files = append(files, File{Path: rel, Content: f.Content})
+		}
+	}
+	return files
+}
+
+// AddFiles adds files generated relative to dir.
+func (l *Layout) AddFiles(dir string, files ...File) {
+	for _, f := range files {
+		f.Path = path.Join(dir, f.Path)
+		l.Files = append(l.Files, f)
+	}
+}
+
+// Kustomizations returns every kustomization of the layout by directory.
+func (l *Layout) Kustomizations() map[string]*Kustomization {
+	ks := map[string]*Kustomization{BaseDir: l.Base}
+	for env, k := range l.Overlays {
+		ks[OverlayDir(env)] = k
+	}
+	for name, k := range l.Components {
+		ks[ComponentDir(name)] = k
+	}
+	for dir, k := range l.ClusterOverlays {
+		ks[dir] = k
+	}
+	return ks
+}
+
+// Render returns all files of the layout, including the kustomizations,
+// sorted by path. Files in the directories of Keep are left out.
+func (l *Layout) Render() ([]File, error) {
+	var files []File
+	for _, f := range l.Files {
+		if !l.kept(f.Path) {
+			files = append(files, f)
+		}
+	}
+	for dir, k := range l.Kustomizations() {
+		if l.kept(dir) {
+			continue
+		}
+		k, err := l.compatible(dir, k)
+		if err != nil {
+			return nil, err
+		}
+		f, err := k.File()
+		if err != nil {
+			return nil, err
+		}
+		if original, ok := l.Existing[dir]; ok {
+			if f.Content, err = mergeKustomization(original, k); err != nil {
+				return nil, fmt.Errorf("%s: %w", dir, err)
+			}
+		}
+		f.Path = path.Join(dir, f.Path)
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// kept reports whether p is in one of the directories of Keep.
+func (l *Layout) kept(p string) bool {
+	for dir := range l.Keep {
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// AppOptions asks for the application name.
+func AppOptions(defaultName string) (string, error) {
+	var app string
+	err := askOne(&survey.Input{
+		Message: "Application name:",
+		Default: defaultName,
+		Help:    "Used for resource names and the app.kubernetes.io/name label.",
+	}, &app, survey.WithValidator(survey.Required), survey.WithValidator(ValidateDNSLabel))
+	return app, err
+}
+
+// EnvironmentOptions asks which environments get an overlay, preselecting
+// the current ones.
+func EnvironmentOptions(current []string) ([]string, error) {
+	options := []string{"dev", "staging", "prod"}
+	selected := options
+	if len(current) > 0 {
+		selected = current
+		for _, env := range current {
+			if !containsString(options, env) {
+				options = append(options, env)
+			}
+		}
+	}
+	var envs []string
+	err := askOne(&survey.MultiSelect{
+		Message: "Environments:",
+		Options: options,
+		Default: selected,
+	}, &envs)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := askList("Additional environment", "", ValidateDNSLabel)
+	if err != nil {
+		return nil, err
+	}
+	envs = append(envs, extra...)
+	if len(envs) == 0 {
+		return nil, fmt.Errorf("at least one environment is required")
+	}
+	return envs, nil
+}
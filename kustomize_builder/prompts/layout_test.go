@@ -0,0 +1,54 @@
+package prompts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLayoutCloneIsDeep(t *testing.T) {
+	l := NewLayout("shop", []string{"dev"})
+	l.Routing.Hosts = map[string][]string{"Public": {"shop.example.com"}}
+	l.Routing.EnvHosts = map[string]map[string][]string{"dev": {"Public": {"shop.dev.example.com"}}}
+	l.Routing.Subsets = []Subset{{Name: "stable", Weight: 100}}
+	l.Clusters = []Cluster{{Name: "eu", Envs: []string{"dev"}, NodeSelector: map[string]string{"zone": "a"}}}
+	l.Keep = map[string]bool{"overlays/dev": true}
+	l.Existing = map[string][]byte{"base": []byte("kind: Kustomization\n")}
+	l.Base.Patches = []Patch{{Path: "patches/p.yaml", Target: &Selector{Kind: "Deployment"}}}
+	l.Base.Labels = []Label{{Pairs: map[string]string{"team": "a"}}}
+	l.Base.Replacements = []Replacement{{
+		Source:  &ReplacementSource{Kind: "ConfigMap", Name: "env"},
+		Targets: []ReplacementTarget{{Select: &Selector{Kind: "Deployment"}, FieldPaths: []string{"spec.replicas"}}},
+	}}
+
+	c := l.Clone()
+	if !reflect.DeepEqual(c, l) {
+		t.Fatalf("clone differs from the layout:\n%+v\n%+v", c, l)
+	}
+
+	c.Routing.Hosts["Public"][0] = "changed"
+	c.Routing.EnvHosts["dev"]["Public"][0] = "changed"
+	c.Routing.Subsets[0].Weight = 50
+	c.Clusters[0].NodeSelector["zone"] = "b"
+	c.Keep["base"] = true
+	c.Existing["base"][0] = 'K'
+	c.Base.Patches[0].Target.Kind = "StatefulSet"
+	c.Base.Labels[0].Pairs["team"] = "b"
+	c.Base.Replacements[0].Source.Name = "changed"
+	c.Base.Replacements[0].Targets[0].Select.Kind = "StatefulSet"
+	c.Base.Replacements[0].Targets[0].FieldPaths[0] = "changed"
+
+	switch {
+	case l.Routing.Hosts["Public"][0] != "shop.example.com",
+		l.Routing.EnvHosts["dev"]["Public"][0] != "shop.dev.example.com",
+		l.Routing.Subsets[0].Weight != 100,
+		l.Clusters[0].NodeSelector["zone"] != "a",
+		l.Keep["base"],
+		l.Existing["base"][0] != 'k',
+		l.Base.Patches[0].Target.Kind != "Deployment",
+		l.Base.Labels[0].Pairs["team"] != "a",
+		l.Base.Replacements[0].Source.Name != "env",
+		l.Base.Replacements[0].Targets[0].Select.Kind != "Deployment",
+		l.Base.Replacements[0].Targets[0].FieldPaths[0] != "spec.replicas":
+		t.Errorf("changing the clone changed the layout: %+v", l)
+	}
+}
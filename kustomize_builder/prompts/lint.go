@@ -0,0 +1,319 @@
+package prompts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Lint checks, after the kube-linter checks of the same names.
+const (
+	checkReadinessProbe = "no-readiness-probe"
+	checkLivenessProbe  = "no-liveness-probe"
+	checkCPULimit       = "unset-cpu-limit"
+	checkMemoryLimit    = "unset-memory-limit"
+	checkRunAsNonRoot   = "run-as-non-root"
+)
+
+var lintMessages = map[string]string{
+	checkReadinessProbe: "has no readiness probe",
+	checkLivenessProbe:  "has no liveness probe",
+	checkCPULimit:       "has no CPU limit",
+	checkMemoryLimit:    "has no memory limit",
+	checkRunAsNonRoot:   "may run as root",
+}
+
+// LintFinding is a best practice a container of the build output does not
+// follow, in the environments listed.
+type LintFinding struct {
+	Check string
+	Kind  string
+	// Name is the workload's name in the base, before name prefixes and
+	// suffixes.
+	Name      string
+	Container string
+	Envs      []string
+
+	apiVersion string
+	// port is the port probes check.
+	port interface{}
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s %s: container %s %s (%s) [%s]", f.Kind, f.Name, f.Container, lintMessages[f.Check], strings.Join(f.Envs, ", "), f.Check)
+}
+
+type lintContainer struct {
+	Name  string `yaml:"name"`
+	Ports []struct {
+		Name          string `yaml:"name"`
+		ContainerPort int    `yaml:"containerPort"`
+	} `yaml:"ports"`
+	ReadinessProbe interface{} `yaml:"readinessProbe"`
+	LivenessProbe  interface{} `yaml:"livenessProbe"`
+	Resources      struct {
+		Limits map[string]interface{} `yaml:"limits"`
+	} `yaml:"resources"`
+	SecurityContext struct {
+		RunAsNonRoot bool `yaml:"runAsNonRoot"`
+	} `yaml:"securityContext"`
+}
+
+type lintPodSpec struct {
+	SecurityContext struct {
+		RunAsNonRoot bool `yaml:"runAsNonRoot"`
+	} `yaml:"securityContext"`
+	Containers []lintContainer `yaml:"containers"`
+}
+
+type lintObject struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Template struct {
+			Spec lintPodSpec `yaml:"spec"`
+		} `yaml:"template"`
+		JobTemplate struct {
+			Spec struct {
+				Template struct {
+					Spec lintPodSpec `yaml:"spec"`
+				} `yaml:"template"`
+			} `yaml:"spec"`
+		} `yaml:"jobTemplate"`
+	} `yaml:"spec"`
+}
+
+// podSpec returns the pod spec of workloads, and whether o is one.
+func (o lintObject) podSpec() (lintPodSpec, bool) {
+	switch o.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "Job":
+		return o.Spec.Template.Spec, true
+	case "CronJob":
+		return o.Spec.JobTemplate.Spec.Template.Spec, true
+	}
+	return lintPodSpec{}, false
+}
+
+// baseName strips the name prefixes and suffixes the overlay of env and
+// the base add.
+func baseName(l *Layout, env, name string) string {
+	for _, k := range []*Kustomization{l.Overlays[env], l.Base} {
+		if k != nil {
+			name = strings.TrimSuffix(strings.TrimPrefix(name, k.NamePrefix), k.NameSuffix)
+		}
+	}
+	return name
+}
+
+// Lint checks the workloads in the build output of every overlay for
+// missing probes, unset resource limits and containers that may run as
+// root. Findings shared by environments are reported once.
+func Lint(l *Layout, rendered map[string][]byte) ([]LintFinding, error) {
+	var findings []LintFinding
+	index := map[string]int{}
+	for _, env := range l.Envs {
+		dec := yaml.NewDecoder(bytes.NewReader(rendered[env]))
+		for {
+			var o lintObject
+			err := dec.Decode(&o)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", env, err)
+			}
+			spec, ok := o.podSpec()
+			if !ok {
+				continue
+			}
+			for _, c := range spec.Containers {
+				var checks []string
+				if o.Kind != "Job" && o.Kind != "CronJob" {
+					if c.ReadinessProbe == nil {
+						checks = append(checks, checkReadinessProbe)
+					}
+					if c.LivenessProbe == nil {
+						checks = append(checks, checkLivenessProbe)
+					}
+				}
+				if c.Resources.Limits["cpu"] == nil {
+					checks = append(checks, checkCPULimit)
+				}
+				if c.Resources.Limits["memory"] == nil {
+					checks = append(checks, checkMemoryLimit)
+				}
+				if !c.SecurityContext.RunAsNonRoot && !spec.SecurityContext.RunAsNonRoot {
+					checks = append(checks, checkRunAsNonRoot)
+				}
+
+				name := baseName(l, env, o.Metadata.Name)
+				for _, check := range checks {
+					key := strings.Join([]string{check, o.Kind, name, c.Name}, "/")
+					if i, ok := index[key]; ok {
+						findings[i].Envs = append(findings[i].Envs, env)
+						continue
+					}
+					f := LintFinding{Check: check, Kind: o.Kind, Name: name, Container: c.Name, Envs: []string{env}, apiVersion: o.APIVersion, port: 8080}
+					if len(c.Ports) > 0 {
+						f.port = c.Ports[0].ContainerPort
+						if c.Ports[0].Name != "" {
+							f.port = c.Ports[0].Name
+						}
+					}
+					index[key] = len(findings)
+					findings = append(findings, f)
+				}
+			}
+		}
+	}
+	return findings, nil
+}
+
+// fixContainer sets what f is missing on the container patch c.
+func fixContainer(c map[string]interface{}, f LintFinding) {
+	switch f.Check {
+	case checkReadinessProbe:
+		c["readinessProbe"] = map[string]interface{}{
+			"tcpSocket":           map[string]interface{}{"port": f.port},
+			"initialDelaySeconds": 5,
+			"periodSeconds":       10,
+		}
+	case checkLivenessProbe:
+		c["livenessProbe"] = map[string]interface{}{
+			"tcpSocket":           map[string]interface{}{"port": f.port},
+			"initialDelaySeconds": 15,
+			"periodSeconds":       20,
+		}
+	case checkCPULimit, checkMemoryLimit:
+		resources, _ := c["resources"].(map[string]interface{})
+		if resources == nil {
+			resources = map[string]interface{}{"limits": map[string]interface{}{}}
+			c["resources"] = resources
+		}
+		limits := resources["limits"].(map[string]interface{})
+		if f.Check == checkCPULimit {
+			limits["cpu"] = "500m"
+		} else {
+			limits["memory"] = "512Mi"
+		}
+	case checkRunAsNonRoot:
+		c["securityContext"] = map[string]interface{}{"runAsNonRoot": true}
+	}
+}
+
+// FixPatches adds strategic merge patches fixing findings to l, one per
+// workload. Findings of all environments are fixed in the base, the others
+// in the overlays they occur in.
+func FixPatches(l *Layout, findings []LintFinding) error {
+	type workload struct{ dir, kind, name string }
+	patches := map[workload]map[string]interface{}{}
+	containers := map[workload]map[string]map[string]interface{}{}
+	var order []workload
+	for _, f := range findings {
+		dirs := []string{BaseDir}
+		if len(f.Envs) < len(l.Envs) {
+			dirs = nil
+			for _, env := range f.Envs {
+				dirs = append(dirs, OverlayDir(env))
+			}
+		}
+		for _, dir := range dirs {
+			w := workload{dir, f.Kind, f.Name}
+			if patches[w] == nil {
+				patches[w] = map[string]interface{}{
+					"apiVersion": f.apiVersion,
+					"kind":       f.Kind,
+					"metadata":   map[string]interface{}{"name": f.Name},
+				}
+				containers[w] = map[string]map[string]interface{}{}
+				order = append(order, w)
+			}
+			c := containers[w][f.Container]
+			if c == nil {
+				c = map[string]interface{}{"name": f.Container}
+				containers[w][f.Container] = c
+			}
+			fixContainer(c, f)
+		}
+	}
+
+	for _, w := range order {
+		names := make([]string, 0, len(containers[w]))
+		for name := range containers[w] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var list []interface{}
+		for _, name := range names {
+			list = append(list, containers[w][name])
+		}
+		podSpec := map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"containers": list}}}
+		spec := podSpec
+		if w.kind == "CronJob" {
+			spec = map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": podSpec}}
+		}
+		patches[w]["spec"] = spec
+
+		content, err := marshalYAML(patches[w])
+		if err != nil {
+			return err
+		}
+		k := l.Base
+		if w.dir != BaseDir {
+			k = l.Overlays[path.Base(w.dir)]
+		}
+		// Patches of earlier runs are on disk only, so keep clear of them too.
+		taken := append([]File(nil), l.Files...)
+		for _, p := range k.Patches {
+			taken = append(taken, File{Path: path.Join(w.dir, p.Path)})
+		}
+		p := uniquePath(path.Join(w.dir, "patches", "lint-"+strings.ToLower(w.kind)+"-"+w.name+".yaml"), taken)
+		l.Files = append(l.Files, File{Path: p, Content: content})
+		k.AddPatch(Patch{Path: strings.TrimPrefix(p, w.dir+"/")})
+	}
+	return nil
+}
+
+// LintOptions prints the lint findings of the build output and, when
+// interactive, offers to generate fix patches for selected ones. It reports
+// whether patches were added, so the overlays need building again.
+func LintOptions(l *Layout, rendered map[string][]byte) (bool, error) {
+	findings, err := Lint(l, rendered)
+	if err != nil || len(findings) == 0 {
+		return false, err
+	}
+	fmt.Fprintln(Out, "Lint findings:")
+	options := make([]string, len(findings))
+	for i, f := range findings {
+		options[i] = f.String()
+		fmt.Fprintln(Out, "- "+options[i])
+	}
+	if !Interactive() {
+		return false, nil
+	}
+
+	var selected []int
+	err = askOne(&survey.MultiSelect{
+		Message: "Generate fix patches for:",
+		Options: options,
+		Help:    "Probes check the first container port, limits default to 500m CPU and 512Mi memory. Review the patches before applying.",
+	}, &selected)
+	if err != nil || len(selected) == 0 {
+		return false, err
+	}
+	var fix []LintFinding
+	for _, i := range selected {
+		fix = append(fix, findings[i])
+	}
+	return true, FixPatches(l, fix)
+}
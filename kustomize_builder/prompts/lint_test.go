@@ -0,0 +1,119 @@
+package prompts
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const lintedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %s
+spec:
+  template:
+    spec:
+      containers:
+        - name: shop
+          ports:
+            - name: http
+              containerPort: 8080
+%s`
+
+func deployment(name, container string) string {
+	return fmt.Sprintf(lintedDeployment, name, container)
+}
+
+func TestLint(t *testing.T) {
+	healthy := `          readinessProbe: {tcpSocket: {port: http}}
+          livenessProbe: {tcpSocket: {port: http}}
+          resources: {limits: {cpu: 500m, memory: 512Mi}}
+          securityContext: {runAsNonRoot: true}
+`
+	for _, tc := range []struct {
+		name     string
+		rendered map[string]string
+		want     []string
+	}{
+		{
+			name:     "healthy",
+			rendered: map[string]string{"dev": deployment("shop", healthy), "prod": deployment("shop", healthy)},
+		},
+		{
+			name: "shared findings are reported once",
+			rendered: map[string]string{
+				"dev":  deployment("dev-shop", ""),
+				"prod": deployment("prod-shop", ""),
+			},
+			want: []string{
+				"no-readiness-probe Deployment shop [dev prod]",
+				"no-liveness-probe Deployment shop [dev prod]",
+				"unset-cpu-limit Deployment shop [dev prod]",
+				"unset-memory-limit Deployment shop [dev prod]",
+				"run-as-non-root Deployment shop [dev prod]",
+			},
+		},
+		{
+			name: "jobs need no probes",
+			rendered: map[string]string{
+				"dev":  "apiVersion: batch/v1\nkind: CronJob\nmetadata:\n  name: report\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          securityContext: {runAsNonRoot: true}\n          containers:\n            - name: report\n              resources: {limits: {cpu: 100m}}\n",
+				"prod": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: report\n",
+			},
+			want: []string{"unset-memory-limit CronJob report [dev]"},
+		},
+	} {
+		l := NewLayout("shop", []string{"dev", "prod"})
+		l.Overlays["dev"].NamePrefix = "dev-"
+		l.Overlays["prod"].NamePrefix = "prod-"
+		rendered := map[string][]byte{}
+		for env, content := range tc.rendered {
+			rendered[env] = []byte(content)
+		}
+		findings, err := Lint(l, rendered)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		var got []string
+		for _, f := range findings {
+			got = append(got, fmt.Sprintf("%s %s %s %v", f.Check, f.Kind, f.Name, f.Envs))
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: findings = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFixPatches(t *testing.T) {
+	l := NewLayout("shop", []string{"dev", "prod"})
+	l.Base.AddPatch(Patch{Path: "patches/lint-deployment-shop.yaml"})
+	findings := []LintFinding{
+		{Check: checkMemoryLimit, Kind: KindDeployment, Name: "shop", Container: "shop", Envs: []string{"dev", "prod"}, apiVersion: "apps/v1"},
+		{Check: checkReadinessProbe, Kind: KindDeployment, Name: "shop", Container: "shop", Envs: []string{"dev", "prod"}, apiVersion: "apps/v1", port: "http"},
+		{Check: checkCPULimit, Kind: KindDeployment, Name: "shop", Container: "shop", Envs: []string{"prod"}, apiVersion: "apps/v1"},
+	}
+	if err := FixPatches(l, findings); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, f := range l.Files {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"base/patches/lint-deployment-shop-2.yaml", "overlays/prod/patches/lint-deployment-shop.yaml"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("patch files = %q, want %q", paths, want)
+	}
+	base := string(l.Files[0].Content)
+	for _, s := range []string{"memory: 512Mi", "readinessProbe:", "port: http"} {
+		if !strings.Contains(base, s) {
+			t.Errorf("base patch lacks %q:\n%s", s, base)
+		}
+	}
+	if prod := string(l.Files[1].Content); !strings.Contains(prod, "cpu: 500m") || strings.Contains(prod, "memory") {
+		t.Errorf("prod patch should only set the CPU limit:\n%s", prod)
+	}
+	if got := l.Overlays["prod"].Patches; len(got) != 1 || got[0].Path != "patches/lint-deployment-shop.yaml" {
+		t.Errorf("prod patches = %+v", got)
+	}
+}
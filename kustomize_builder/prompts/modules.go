@@ -0,0 +1,26 @@
+package prompts
+
+func init() {
+	// Modules run in registration order. The backend and security modules
+	// depend on the routing choice, so they register after it, as the modules
+	// of each workload kind do after the workload. The workload comes first,
+	// for the others to select.
+	Register(workloadModule{})
+	Register(deploymentModule{})
+	Register(statefulSetModule{})
+	Register(cronJobModule{})
+	Register(jobModule{})
+	Register(routingModule{})
+	Register(certManagerModule{})
+	Register(canaryModule{})
+	Register(istioModule{})
+	Register(gatewayAPIModule{})
+	Register(ingressModule{})
+	Register(istioAuthzModule{})
+	Register(peerAuthModule{})
+	Register(sidecarModule{})
+	Register(networkPolicyModule{})
+	Register(availabilityModule{})
+	Register(monitoringModule{})
+	Register(rbacModule{})
+}
@@ -0,0 +1,195 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Prometheus Operator resources the Monitoring module scrapes the app with.
+const (
+	serviceMonitor = "ServiceMonitor"
+	podMonitor     = "PodMonitor"
+)
+
+// promDuration matches Prometheus durations such as 30s or 1m30s.
+var promDuration = regexp.MustCompile(`^([0-9]+y)?([0-9]+w)?([0-9]+d)?([0-9]+h)?([0-9]+m)?([0-9]+s)?([0-9]+ms)?$`)
+
+// validatePromDuration accepts Prometheus durations such as 30s or 1m30s.
+func validatePromDuration(ans interface{}) error {
+	s := answer(ans)
+	if s == "" || !promDuration.MatchString(s) {
+		return fmt.Errorf("%q is not a duration like 30s or 1m", s)
+	}
+	return nil
+}
+
+// validatePortName accepts the names of container and Service ports.
+func validatePortName(ans interface{}) error {
+	s := answer(ans)
+	return validationError(s, validation.IsValidPortName(s))
+}
+
+// monitoringModule has Prometheus scrape the app's metrics through a
+// ServiceMonitor or PodMonitor, with a scrape interval per environment, and
+// optionally ships a Grafana dashboard for them.
+type monitoringModule struct{}
+
+func (monitoringModule) Name() string { return "Monitoring" }
+
+func (monitoringModule) OptIn() string { return "Does the app expose Prometheus metrics?" }
+
+func (monitoringModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "monitor",
+			Prompt: &survey.Select{
+				Message: "Scrape with:",
+				Options: []string{serviceMonitor, podMonitor},
+				Help:    "ServiceMonitors scrape the pods behind the app's Service, PodMonitors the pods directly, e.g. of Jobs.",
+			},
+		},
+		{
+			Name:     "port",
+			Prompt:   &survey.Input{Message: "Metrics port name:", Default: "http"},
+			Validate: validatePortName,
+		},
+		{
+			Name:     "path",
+			Prompt:   &survey.Input{Message: "Metrics path:", Default: "/metrics"},
+			Validate: validateIngressPath,
+		},
+		{
+			Name:     "interval",
+			Prompt:   &survey.Input{Message: "Scrape interval:", Default: "30s"},
+			Validate: validatePromDuration,
+		},
+		{
+			Name: "labels",
+			Prompt: &survey.Input{
+				Message: "Labels Prometheus selects monitors by, KEY=VALUE comma separated (optional):",
+				Help:    "E.g. release=prometheus for kube-prometheus-stack.",
+			},
+			Validate: listOf(ValidateLabel),
+		},
+		{
+			Name:   "dashboard",
+			Prompt: &survey.Confirm{Message: "Generate a Grafana dashboard ConfigMap?"},
+		},
+	}
+}
+
+func (monitoringModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	kind, interval := answers.String("monitor"), answers.String("interval")
+	labels := appLabels(l.App)
+	for _, label := range splitList(answers.String("labels")) {
+		key, value, _ := strings.Cut(label, "=")
+		labels[key] = value
+	}
+	endpoints := "endpoints"
+	if kind == podMonitor {
+		endpoints = "podMetricsEndpoints"
+	}
+	file := strings.ToLower(kind) + ".yaml"
+	manifests := []workloadManifest{{file, map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": l.App, "labels": labels},
+		"spec": map[string]interface{}{
+			"selector": map[string]interface{}{"matchLabels": appLabels(l.App)},
+			endpoints: []interface{}{
+				map[string]interface{}{
+					"port":     answers.String("port"),
+					"path":     answers.String("path"),
+					"interval": interval,
+				},
+			},
+		},
+	}}}
+	if answers.Bool("dashboard") {
+		dashboard, err := grafanaDashboard(l.App)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, workloadManifest{"dashboard.yaml", dashboard})
+	}
+	files, err := baseResources(l, manifests...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, env := range l.Envs {
+		envInterval := interval
+		err := askOne(&survey.Input{Message: "Scrape interval for " + env + ":", Default: interval}, &envInterval,
+			survey.WithValidator(validatePromDuration))
+		if err != nil {
+			return nil, err
+		}
+		if envInterval == interval {
+			continue
+		}
+		content, err := marshalYAML([]jsonPatchOp{{Op: "replace", Path: "/spec/" + endpoints + "/0/interval", Value: envInterval}})
+		if err != nil {
+			return nil, err
+		}
+		p := path.Join("patches", "scrape-interval.yaml")
+		files = append(files, File{Path: path.Join(OverlayDir(env), p), Content: content})
+		l.Overlays[env].AddPatch(Patch{Path: p, Target: &Selector{Group: "monitoring.coreos.com", Kind: kind, Name: l.App}})
+	}
+	return files, nil
+}
+
+// grafanaDashboard returns a ConfigMap holding a dashboard of the app's
+// process metrics, labeled for the Grafana sidecar of kube-prometheus-stack
+// to load it.
+func grafanaDashboard(app string) (map[string]interface{}, error) {
+	selector := fmt.Sprintf(`namespace="$namespace", pod=~"%s-.*"`, app)
+	var panels []interface{}
+	for i, p := range []struct{ title, expr string }{
+		{"Targets up", "sum(up{" + selector + "})"},
+		{"CPU", "sum by (pod) (rate(process_cpu_seconds_total{" + selector + "}[5m]))"},
+		{"Memory", "sum by (pod) (process_resident_memory_bytes{" + selector + "})"},
+	} {
+		panels = append(panels, map[string]interface{}{
+			"id":      i + 1,
+			"type":    "timeseries",
+			"title":   p.title,
+			"gridPos": map[string]interface{}{"x": 8 * i, "y": 0, "w": 8, "h": 8},
+			"targets": []interface{}{map[string]interface{}{"expr": p.expr, "refId": "A"}},
+		})
+	}
+	dashboard, err := json.MarshalIndent(map[string]interface{}{
+		"title":         app,
+		"uid":           app,
+		"schemaVersion": 39,
+		"panels":        panels,
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"name": "datasource", "type": "datasource", "query": "prometheus"},
+				map[string]interface{}{
+					"name":       "namespace",
+					"type":       "query",
+					"datasource": "$datasource",
+					"query":      fmt.Sprintf(`label_values(up{pod=~"%s-.*"}, namespace)`, app),
+				},
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	labels := appLabels(app)
+	labels["grafana_dashboard"] = "1"
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": app + "-dashboard", "labels": labels},
+		"data":       map[string]interface{}{app + ".json": string(dashboard) + "\n"},
+	}, nil
+}
@@ -0,0 +1,77 @@
+package prompts
+
+import (
+	"github.com/AlecAivazis/survey/v2"
+)
+
+type namespaceManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   map[string]string `yaml:"metadata"`
+}
+
+// NamespaceOptions asks for the target namespace and name prefix/suffix and
+// sets them on k. When requested it also returns a Namespace manifest, which
+// is added to the kustomization's resources.
+func NamespaceOptions(k *Kustomization) ([]File, error) {
+	answers := struct {
+		Namespace  string
+		Create     bool
+		NamePrefix string
+		NameSuffix string
+	}{}
+
+	err := askOne(&survey.Input{
+		Message: "Target namespace:",
+		Default: k.Namespace,
+		Help:    "Sets the namespace: field of the kustomization. Leave empty to keep the namespaces of the resources.",
+	}, &answers.Namespace, survey.WithValidator(Optional(ValidateDNSLabel)))
+	if err != nil {
+		return nil, err
+	}
+
+	if answers.Namespace != "" && !containsString(k.Resources, "namespace.yaml") {
+		err = askOne(&survey.Confirm{
+			Message: "Generate a Namespace manifest for " + answers.Namespace + "?",
+			Default: true,
+		}, &answers.Create)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	qs := []*survey.Question{
+		{
+			Name:     "NamePrefix",
+			Prompt:   &survey.Input{Message: "Name prefix (optional):", Default: k.NamePrefix},
+			Validate: validateNameAffix,
+		},
+		{
+			Name:     "NameSuffix",
+			Prompt:   &survey.Input{Message: "Name suffix (optional):", Default: k.NameSuffix},
+			Validate: validateNameAffix,
+		},
+	}
+	if err := ask(qs, &answers); err != nil {
+		return nil, err
+	}
+
+	k.Namespace = answers.Namespace
+	k.NamePrefix = answers.NamePrefix
+	k.NameSuffix = answers.NameSuffix
+
+	if !answers.Create {
+		return nil, nil
+	}
+
+	content, err := marshalYAML(namespaceManifest{
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Metadata:   map[string]string{"name": answers.Namespace},
+	})
+	if err != nil {
+		return nil, err
+	}
+	k.AddResource("namespace.yaml")
+	return []File{{Path: "namespace.yaml", Content: content}}, nil
+}
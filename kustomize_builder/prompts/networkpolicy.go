@@ -0,0 +1,186 @@
+package prompts
+
+import (
+	"path"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Egress destinations offered by the NetworkPolicy module.
+const (
+	egressDNS      = "DNS"
+	egressSameNS   = "Same namespace"
+	egressInternet = "Internet"
+)
+
+// privateRanges are the RFC 1918 ranges excluded from Internet egress.
+var privateRanges = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+
+// networkPolicyModule isolates the app's pods with a default-deny
+// NetworkPolicy and allows the selected traffic, including that from the
+// routing backend's gateways.
+type networkPolicyModule struct{}
+
+func (networkPolicyModule) Name() string { return "Network policies" }
+
+func (networkPolicyModule) OptIn() string { return "Generate NetworkPolicies?" }
+
+func (networkPolicyModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:     "port",
+			Prompt:   &survey.Input{Message: "Container port to allow traffic to:", Default: "8080"},
+			Validate: ValidatePort,
+		},
+		{
+			Name:   "sameNamespace",
+			Prompt: &survey.Confirm{Message: "Allow traffic from pods in the same namespace?", Default: true},
+		},
+		{
+			Name:     "ingressNamespaces",
+			Prompt:   &survey.Input{Message: "Other namespaces allowed to connect, comma separated (optional):"},
+			Validate: listOf(ValidateDNSLabel),
+		},
+		{
+			Name:     "ingressCIDRs",
+			Prompt:   &survey.Input{Message: "CIDRs allowed to connect, comma separated (optional):"},
+			Validate: listOf(ValidateCIDR),
+		},
+		{
+			Name: "egress",
+			Prompt: &survey.MultiSelect{
+				Message: "Allowed egress:",
+				Options: []string{egressDNS, egressSameNS, egressInternet},
+				Default: []string{egressDNS},
+				Help:    "Internet excludes the private address ranges.",
+			},
+		},
+		{
+			Name:     "egressCIDRs",
+			Prompt:   &survey.Input{Message: "Other CIDRs the app connects to, comma separated (optional):"},
+			Validate: listOf(ValidateCIDR),
+		},
+	}
+}
+
+func namespacePeer(namespace string) map[string]interface{} {
+	return map[string]interface{}{
+		"namespaceSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{"kubernetes.io/metadata.name": namespace},
+		},
+	}
+}
+
+// gatewayPeer selects the pods of the routing backend's gateways for an
+// exposure.
+func gatewayPeer(backend, exposure string) map[string]interface{} {
+	switch backend {
+	case BackendIstio:
+		peer := namespacePeer("istio-system")
+		selector := "ingressgateway"
+		if exposure == "Private" {
+			selector = "internal-ingressgateway"
+		}
+		peer["podSelector"] = map[string]interface{}{"matchLabels": map[string]interface{}{"istio": selector}}
+		return peer
+	case BackendGatewayAPI:
+		return namespacePeer(GatewayNamespace)
+	}
+	return namespacePeer("ingress-nginx")
+}
+
+func (networkPolicyModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	port, err := strconv.Atoi(answers.String("port"))
+	if err != nil {
+		return nil, err
+	}
+
+	var from []interface{}
+	if answers.Bool("sameNamespace") {
+		from = append(from, map[string]interface{}{"podSelector": map[string]interface{}{}})
+	}
+	for _, exposure := range l.Routing.Exposures() {
+		from = append(from, gatewayPeer(l.Routing.Backend, exposure))
+	}
+	for _, ns := range splitList(answers.String("ingressNamespaces")) {
+		from = append(from, namespacePeer(ns))
+	}
+	for _, cidr := range splitList(answers.String("ingressCIDRs")) {
+		from = append(from, map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": cidr}})
+	}
+
+	var egress []interface{}
+	for _, e := range answers.Strings("egress") {
+		switch e {
+		case egressDNS:
+			peer := namespacePeer("kube-system")
+			peer["podSelector"] = map[string]interface{}{"matchLabels": map[string]interface{}{"k8s-app": "kube-dns"}}
+			egress = append(egress, map[string]interface{}{
+				"to": []interface{}{peer},
+				"ports": []interface{}{
+					map[string]interface{}{"protocol": "UDP", "port": 53},
+					map[string]interface{}{"protocol": "TCP", "port": 53},
+				},
+			})
+		case egressSameNS:
+			egress = append(egress, map[string]interface{}{
+				"to": []interface{}{map[string]interface{}{"podSelector": map[string]interface{}{}}},
+			})
+		case egressInternet:
+			egress = append(egress, map[string]interface{}{
+				"to": []interface{}{map[string]interface{}{
+					"ipBlock": map[string]interface{}{"cidr": "0.0.0.0/0", "except": privateRanges},
+				}},
+			})
+		}
+	}
+	for _, cidr := range splitList(answers.String("egressCIDRs")) {
+		egress = append(egress, map[string]interface{}{
+			"to": []interface{}{map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": cidr}}},
+		})
+	}
+
+	policies := []struct {
+		file     string
+		manifest map[string]interface{}
+	}{
+		{"networkpolicy-default-deny.yaml", networkPolicy(l.App+"-default-deny", l.App, nil)},
+		{"networkpolicy-" + l.App + ".yaml", networkPolicy(l.App, l.App, map[string]interface{}{
+			"ingress": []interface{}{map[string]interface{}{
+				"from":  from,
+				"ports": []interface{}{map[string]interface{}{"protocol": "TCP", "port": port}},
+			}},
+			"egress": egress,
+		})},
+	}
+	var files []File
+	for _, p := range policies {
+		content, err := marshalYAML(p.manifest)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, p.file), Content: content})
+		l.Base.AddResource(p.file)
+	}
+	return files, nil
+}
+
+// networkPolicy returns a NetworkPolicy of both policy types selecting the
+// app's pods, with the given rules.
+func networkPolicy(name, app string, rules map[string]interface{}) map[string]interface{} {
+	spec := map[string]interface{}{
+		"podSelector": map[string]interface{}{"matchLabels": appLabels(app)},
+		"policyTypes": []string{"Ingress", "Egress"},
+	}
+	for key, value := range rules {
+		spec[key] = value
+	}
+	return map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       spec,
+	}
+}
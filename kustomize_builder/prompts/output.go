@@ -0,0 +1,121 @@
+package prompts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the generated manifests.
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Out receives what is printed for the user: prompts, notes, findings and
+// previews. It is stdout unless the manifests are printed there, as they are
+// with the JSON output format, when main points it at stderr.
+var Out io.Writer = os.Stdout
+
+// ToJSON returns files with their YAML converted to indented JSON, which
+// kustomize reads as well, so file names and references are kept. Files
+// holding several documents become a v1 List. Files that are not YAML, such
+// as the env files of generators, are left alone.
+func ToJSON(files []File) ([]File, error) {
+	converted := make([]File, len(files))
+	for i, f := range files {
+		converted[i] = f
+		if ext := filepath.Ext(f.Path); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		var docs []interface{}
+		dec := yaml.NewDecoder(bytes.NewReader(f.Content))
+		for {
+			var doc interface{}
+			err := dec.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Path, err)
+			}
+			if doc != nil {
+				docs = append(docs, doc)
+			}
+		}
+		var v interface{}
+		switch len(docs) {
+		case 0:
+			continue
+		case 1:
+			v = docs[0]
+		default:
+			v = map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": docs}
+		}
+		content, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		converted[i].Content = append(content, '\n')
+	}
+	return converted, nil
+}
+
+// Manifest lists the generated files for tools reading the output.
+type Manifest struct {
+	OutDir  string          `json:"outDir"`
+	Format  string          `json:"format"`
+	Written bool            `json:"written"`
+	Files   []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry is a generated file, with Status create, modify or
+// unchanged relative to what is on disk.
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewManifest describes writing files to dir.
+func NewManifest(dir, format string, files []File) (*Manifest, error) {
+	m := &Manifest{OutDir: dir, Format: format, Files: []ManifestEntry{}}
+	for _, f := range files {
+		sum := sha256.Sum256(f.Content)
+		e := ManifestEntry{Path: f.Path, Status: "modify", SHA256: hex.EncodeToString(sum[:])}
+		existing, err := os.ReadFile(filepath.Join(dir, f.Path))
+		switch {
+		case os.IsNotExist(err):
+			e.Status = "create"
+		case err != nil:
+			return nil, err
+		case bytes.Equal(existing, f.Content):
+			e.Status = "unchanged"
+		}
+		m.Files = append(m.Files, e)
+	}
+	return m, nil
+}
+
+// Write writes m to w as JSON.
+func (m *Manifest) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// ValidateFormat accepts the supported output formats.
+func ValidateFormat(format string) error {
+	if format != FormatYAML && format != FormatJSON {
+		return fmt.Errorf("unknown output format %q, expected %s or %s", format, FormatYAML, FormatJSON)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package prompts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Patch is an entry of the patches field.
+type Patch struct {
+	Path   string    `yaml:"path,omitempty"`
+	Patch  string    `yaml:"patch,omitempty"`
+	Target *Selector `yaml:"target,omitempty"`
+}
+
+// JSON6902Patch is an entry of the patchesJson6902 field, which older
+// kustomize versions have instead of targeted patches.
+type JSON6902Patch struct {
+	Target *Selector `yaml:"target"`
+	Path   string    `yaml:"path"`
+}
+
+// Selector selects the resources a patch applies to.
+type Selector struct {
+	Group              string `yaml:"group,omitempty"`
+	Version            string `yaml:"version,omitempty"`
+	Kind               string `yaml:"kind,omitempty"`
+	Name               string `yaml:"name,omitempty"`
+	Namespace          string `yaml:"namespace,omitempty"`
+	LabelSelector      string `yaml:"labelSelector,omitempty"`
+	AnnotationSelector string `yaml:"annotationSelector,omitempty"`
+}
+
+// clone returns a copy of s, nil when s is.
+func (s *Selector) clone() *Selector {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+type jsonPatchOp struct {
+	Op    string      `yaml:"op"`
+	Path  string      `yaml:"path"`
+	Value interface{} `yaml:"value"`
+}
+
+// MarshalYAML leaves out the value of remove operations only, keeping zero
+// values such as replicas: 0 of the others.
+func (op jsonPatchOp) MarshalYAML() (interface{}, error) {
+	if op.Op == "remove" {
+		return struct {
+			Op   string `yaml:"op"`
+			Path string `yaml:"path"`
+		}{op.Op, op.Path}, nil
+	}
+	type plain jsonPatchOp
+	return plain(op), nil
+}
+
+const (
+	patchStrategicMerge = "Strategic merge"
+	patchJSON6902       = "JSON 6902"
+	otherResource       = "Other (enter manually)"
+)
+
+var (
+	fieldPath   = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)*$`)
+	jsonPointer = regexp.MustCompile(`^(/([^/~]|~[01])*)+$`)
+)
+
+func validateFieldPath(ans interface{}) error {
+	s, _ := ans.(string)
+	if !fieldPath.MatchString(s) {
+		return fmt.Errorf("%q is not a field path like spec.template.spec.serviceAccountName", s)
+	}
+	return nil
+}
+
+func validateJSONPointer(ans interface{}) error {
+	s, _ := ans.(string)
+	if !jsonPointer.MatchString(s) {
+		return fmt.Errorf("%q is not a JSON pointer like /spec/replicas", s)
+	}
+	return nil
+}
+
+func validateYAMLValue(ans interface{}) error {
+	s, _ := ans.(string)
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return fmt.Errorf("value is not valid YAML: %v", err)
+	}
+	return nil
+}
+
+func parseValue(s string) interface{} {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	return v
+}
+
+// PatchOptions builds patches interactively against the resources of the
+// base of l, read from baseDir or generated, and registers the written patch
+// files in the base.
+func PatchOptions(l *Layout, baseDir string) ([]File, error) {
+	k := l.Base
+	ids, err := ReadResourceIDs(baseDir, k.Resources, l.Generated(BaseDir))
+	if err != nil {
+		return nil, err
+	}
+	// Patches of earlier runs are on disk only, so keep clear of the files
+	// the base references too, including those of patches not kept.
+	taken := l.Generated(BaseDir)
+	for _, p := range k.localPaths() {
+		taken = append(taken, File{Path: p})
+	}
+
+	k.Patches, err = keepEntries("Keep patches:", k.Patches, func(p Patch) string {
+		if p.Path != "" {
+			return p.Path
+		}
+		return "inline patch"
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	more, err := askMore("Add a patch?")
+	for ; err == nil && more; more, err = askMore("Add another patch?") {
+		target, err := askTarget(ids)
+		if err != nil {
+			return nil, err
+		}
+		var kind string
+		err = askOne(&survey.Select{
+			Message: "Patch type:",
+			Options: []string{patchStrategicMerge, patchJSON6902},
+		}, &kind)
+		if err != nil {
+			return nil, err
+		}
+
+		var content []byte
+		if kind == patchStrategicMerge {
+			content, err = strategicMergePatch(target)
+		} else {
+			content, err = jsonPatch()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		path := fmt.Sprintf("patches/%s-%s.yaml", strings.ToLower(target.Kind), target.Name)
+		path = uniquePath(path, append(taken, files...))
+		files = append(files, File{Path: path, Content: content})
+
+		p := Patch{Path: path}
+		if kind == patchJSON6902 {
+			p.Target = &Selector{Group: target.Group, Version: target.Version, Kind: target.Kind, Name: target.Name}
+		}
+		k.AddPatch(p)
+	}
+	return files, err
+}
+
+func askTarget(ids []ResourceID) (ResourceID, error) {
+	var id ResourceID
+	if len(ids) > 0 {
+		options := make([]string, 0, len(ids)+1)
+		for _, r := range ids {
+			options = append(options, r.String())
+		}
+		options = append(options, otherResource)
+		var choice int
+		if err := askOne(&survey.Select{Message: "Patch target:", Options: options}, &choice); err != nil {
+			return id, err
+		}
+		if choice < len(ids) {
+			return ids[choice], nil
+		}
+	}
+
+	qs := []*survey.Question{
+		{Name: "Group", Prompt: &survey.Input{Message: "Target group (empty for core):"}},
+		{Name: "Version", Prompt: &survey.Input{Message: "Target version:", Default: "v1"}, Validate: survey.Required},
+		{Name: "Kind", Prompt: &survey.Input{Message: "Target kind:"}, Validate: survey.Required},
+		{Name: "Name", Prompt: &survey.Input{Message: "Target name:"}, Validate: survey.Required},
+	}
+	err := ask(qs, &id)
+	return id, err
+}
+
+func strategicMergePatch(target ResourceID) ([]byte, error) {
+	patch := map[string]interface{}{
+		"apiVersion": apiVersion(target),
+		"kind":       target.Kind,
+		"metadata":   map[string]interface{}{"name": target.Name},
+	}
+	for {
+		var path string
+		err := askOne(&survey.Input{Message: "Field path (empty to finish)"}, &path,
+			survey.WithValidator(func(ans interface{}) error {
+				if s, _ := ans.(string); s == "" {
+					return nil
+				}
+				return validateFieldPath(ans)
+			}))
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			break
+		}
+		var value string
+		err = askOne(&survey.Input{Message: "Value for " + path + ":"}, &value,
+			survey.WithValidator(validateYAMLValue))
+		if err != nil {
+			return nil, err
+		}
+		if err := setField(patch, strings.Split(path, "."), parseValue(value)); err != nil {
+			return nil, err
+		}
+	}
+	return marshalYAML(patch)
+}
+
+// setField sets value at the nested map path, creating maps as needed.
+func setField(obj map[string]interface{}, path []string, value interface{}) error {
+	for i, key := range path[:len(path)-1] {
+		next, ok := obj[key]
+		if !ok {
+			m := map[string]interface{}{}
+			obj[key] = m
+			obj = m
+			continue
+		}
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is already set to a value", strings.Join(path[:i+1], "."))
+		}
+		obj = m
+	}
+	obj[path[len(path)-1]] = value
+	return nil
+}
+
+func jsonPatch() ([]byte, error) {
+	var ops []jsonPatchOp
+	for {
+		var op jsonPatchOp
+		err := askOne(&survey.Select{
+			Message: "Operation:",
+			Options: []string{"add", "replace", "remove", "done"},
+		}, &op.Op)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "done" {
+			break
+		}
+		err = askOne(&survey.Input{Message: "Path:"}, &op.Path,
+			survey.WithValidator(validateJSONPointer))
+		if err != nil {
+			return nil, err
+		}
+		if op.Op != "remove" {
+			var value string
+			err = askOne(&survey.Input{Message: "Value:"}, &value,
+				survey.WithValidator(validateYAMLValue))
+			if err != nil {
+				return nil, err
+			}
+			op.Value = parseValue(value)
+		}
+		ops = append(ops, op)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("patch has no operations")
+	}
+	return marshalYAML(ops)
+}
+
+// uniquePath appends a counter to path if files already contains it.
+func uniquePath(path string, files []File) string {
+	taken := map[string]bool{}
+	for _, f := range files {
+		taken[f.Path] = true
+	}
+	if !taken[path] {
+		return path
+	}
+	base := strings.TrimSuffix(path, ".yaml")
+	for i := 2; ; i++ {
+		p := fmt.Sprintf("%s-%d.yaml", base, i)
+		if !taken[p] {
+			return p
+		}
+	}
+}
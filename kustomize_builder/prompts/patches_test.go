@@ -0,0 +1,65 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestJSONPatchKeepsZeroValues(t *testing.T) {
+	for _, tc := range []struct {
+		op   jsonPatchOp
+		want string
+	}{
+		{jsonPatchOp{Op: "replace", Path: "/spec/replicas", Value: 0}, "- op: replace\n  path: /spec/replicas\n  value: 0\n"},
+		{jsonPatchOp{Op: "add", Path: "/spec/suspend", Value: false}, "- op: add\n  path: /spec/suspend\n  value: false\n"},
+		{jsonPatchOp{Op: "add", Path: "/metadata/annotations/note", Value: ""}, "- op: add\n  path: /metadata/annotations/note\n  value: \"\"\n"},
+		{jsonPatchOp{Op: "remove", Path: "/spec/replicas"}, "- op: remove\n  path: /spec/replicas\n"},
+	} {
+		got, err := marshalYAML([]jsonPatchOp{tc.op})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s %s:\n%s\nwant:\n%s", tc.op.Op, tc.op.Path, got, tc.want)
+		}
+	}
+}
+
+func TestPatchOptionsKeepsClearOfExistingPatches(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "patches", "deployment-shop.yaml")
+	if err := os.MkdirAll(filepath.Dir(existing), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("kept\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l := NewLayout("shop", []string{"dev"})
+	l.Files = []File{{Path: "base/deployment.yaml", Content: []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: shop\n")}}
+	l.Base.AddResource("deployment.yaml")
+	l.Base.AddPatch(Patch{Path: "patches/deployment-shop.yaml"})
+
+	sc := NewScript(strings.NewReader(strings.Join([]string{
+		"", // Keep patches: all
+		"y", "1", patchJSON6902,
+		"replace", "/spec/replicas", "0",
+		"done",
+		"n",
+	}, "\n") + "\n"))
+	var files []File
+	err := sc.Run(func() (err error) {
+		files, err = PatchOptions(l, dir)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("%v\n%s", err, sc.Transcript.String())
+	}
+	if len(files) != 1 || files[0].Path != "patches/deployment-shop-2.yaml" {
+		t.Fatalf("files = %+v, want patches/deployment-shop-2.yaml", files)
+	}
+	if !strings.Contains(string(files[0].Content), "value: 0") {
+		t.Errorf("patch lost the zero value:\n%s", files[0].Content)
+	}
+}
@@ -0,0 +1,324 @@
+package prompts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// Plain replaces the interactive prompts with line-based questions read from
+// stdin, and turns off colors and the pager. It suits CI logs, dumb
+// terminals and screen readers.
+var Plain bool
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// selectOpts make long selection lists filterable and show more of them.
+var selectOpts = []survey.AskOpt{survey.WithFilter(fuzzyMatch), survey.WithPageSize(15)}
+
+// surveyOpts returns opts with the selection options, asking on Out.
+func surveyOpts(opts []survey.AskOpt) []survey.AskOpt {
+	all := append(append([]survey.AskOpt(nil), selectOpts...), opts...)
+	if f, ok := Out.(*os.File); ok {
+		all = append(all, survey.WithStdio(os.Stdin, f, os.Stderr))
+	}
+	return all
+}
+
+// askOne is survey.AskOne, answered line by line in Plain mode, in the
+// terminal UI when it runs and from the script when one runs.
+func askOne(p survey.Prompt, response interface{}, opts ...survey.AskOpt) (err error) {
+	if recorded != nil {
+		defer func() {
+			if err == nil {
+				record(p, response)
+			}
+		}()
+	}
+	if !Plain && session == nil && script == nil {
+		q := &survey.Question{Prompt: shown(p), Transform: stored(p)}
+		return survey.Ask([]*survey.Question{q}, response, surveyOpts(opts)...)
+	}
+	var o survey.AskOptions
+	for _, opt := range opts {
+		if err := opt(&o); err != nil {
+			return err
+		}
+	}
+	return askDirect(p, o.Validators, func(v interface{}) error {
+		return core.WriteAnswer(response, "", v)
+	})
+}
+
+// ask is survey.Ask, answered like askOne.
+func ask(qs []*survey.Question, response interface{}, opts ...survey.AskOpt) error {
+	if !Plain && session == nil && script == nil {
+		asked := make([]*survey.Question, len(qs))
+		for i, q := range qs {
+			c := *q
+			c.Prompt = shown(q.Prompt)
+			if c.Transform == nil {
+				c.Transform = stored(q.Prompt)
+			}
+			if q.Validate != nil {
+				c.Validate = untranslated(q.Prompt, []survey.Validator{q.Validate})[0]
+			}
+			asked[i] = &c
+		}
+		return survey.Ask(asked, response, surveyOpts(opts)...)
+	}
+	for _, q := range qs {
+		var validators []survey.Validator
+		if q.Validate != nil {
+			validators = append(validators, q.Validate)
+		}
+		err := askDirect(q.Prompt, validators, func(v interface{}) error {
+			return core.WriteAnswer(response, q.Name, v)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stored converts the answers to p, asked translated, before they are
+// stored: Input answers are trimmed, as the validators check them trimmed
+// while survey stores them as typed, and selected options are mapped back
+// to the options of p.
+func stored(p survey.Prompt) survey.Transformer {
+	switch p.(type) {
+	case *survey.Input:
+		return survey.TransformString(strings.TrimSpace)
+	case *survey.Select, *survey.MultiSelect:
+		return func(ans interface{}) interface{} { return english(p, ans) }
+	}
+	return nil
+}
+
+// untranslated makes validators check the answers to the translated copy of
+// p as answers to p.
+func untranslated(p survey.Prompt, validators []survey.Validator) []survey.Validator {
+	checks := make([]survey.Validator, len(validators))
+	for i, validate := range validators {
+		checks[i] = func(ans interface{}) error { return validate(english(p, ans)) }
+	}
+	return checks
+}
+
+// askDirect asks p without survey: from the script or in the terminal UI
+// when they run, and line by line otherwise. Scripts answer p by its English
+// message and options; the user is shown them translated.
+func askDirect(p survey.Prompt, validators []survey.Validator, write func(interface{}) error) error {
+	if script != nil {
+		return askScript(p, validators, write)
+	}
+	validators = untranslated(p, validators)
+	asked := func(v interface{}) error { return write(english(p, v)) }
+	if session != nil {
+		return askTUI(shown(p), validators, asked)
+	}
+	return askPlain(shown(p), validators, asked)
+}
+
+// readLine reads an answer, failing when stdin ends before one is given.
+func readLine() (string, error) {
+	line, err := stdin.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return "", fmt.Errorf("no answer on stdin")
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// askPlain asks p until its answer passes the validators, then writes it.
+func askPlain(p survey.Prompt, validators []survey.Validator, write func(interface{}) error) error {
+	filterOptions(p, "")
+	for {
+		message, help, hint := plainPrompt(p)
+		if help != "" {
+			hint += ", ? for help"
+		}
+		fmt.Fprintf(Out, "%s [%s] ", message, hint)
+		line, err := readLine()
+		if err != nil {
+			return err
+		}
+		if line == "?" && help != "" {
+			fmt.Fprintln(Out, help)
+			continue
+		}
+		if strings.HasPrefix(line, "/") && filterOptions(p, line[1:]) {
+			continue
+		}
+		v, err := plainAnswer(p, line)
+		for _, validate := range validators {
+			if err == nil {
+				err = validate(v)
+			}
+		}
+		if err != nil {
+			fmt.Fprintln(Out, "Invalid answer:", err)
+			continue
+		}
+		return write(v)
+	}
+}
+
+// plainPrompt returns the message and help of p, and a hint at what to
+// answer.
+func plainPrompt(p survey.Prompt) (message, help, hint string) {
+	switch p := p.(type) {
+	case *survey.Input:
+		return p.Message, p.Help, "default " + strconv.Quote(p.Default)
+	case *survey.Password:
+		return p.Message, p.Help, "input is shown"
+	case *survey.Confirm:
+		if p.Default {
+			return p.Message, p.Help, "Y/n"
+		}
+		return p.Message, p.Help, "y/N"
+	case *survey.Select:
+		return p.Message, p.Help, "number, /text to filter, default " + strconv.Quote(selectDefault(p))
+	case *survey.MultiSelect:
+		return p.Message, p.Help, "comma separated numbers, /text to filter, - for none, default " + strings.Join(multiSelectDefault(p), ",")
+	}
+	return fmt.Sprint(p), "", ""
+}
+
+// printOptions lists the options matching filter with their numbers.
+func printOptions(options []string, filter string) {
+	for i, o := range options {
+		if fuzzyMatch(filter, o, i) {
+			fmt.Fprintf(Out, "  %d) %s\n", i+1, o)
+		}
+	}
+}
+
+// filterOptions lists the options of selections matching filter, reporting
+// whether p is one.
+func filterOptions(p survey.Prompt, filter string) bool {
+	switch p := p.(type) {
+	case *survey.Select:
+		printOptions(p.Options, filter)
+	case *survey.MultiSelect:
+		printOptions(p.Options, filter)
+	default:
+		return false
+	}
+	return true
+}
+
+// fuzzyMatch is the filter of selection lists: each word of filter must
+// occur in value as a subsequence, ignoring case, so "ngx ing" finds
+// "ingress-nginx-ingress".
+func fuzzyMatch(filter, value string, index int) bool {
+	value = strings.ToLower(value)
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		rest := value
+		for _, r := range word {
+			i := strings.IndexRune(rest, r)
+			if i < 0 {
+				return false
+			}
+			rest = rest[i+len(string(r)):]
+		}
+	}
+	return true
+}
+
+func selectDefault(p *survey.Select) string {
+	switch d := p.Default.(type) {
+	case string:
+		return d
+	case int:
+		if d >= 0 && d < len(p.Options) {
+			return p.Options[d]
+		}
+	}
+	if len(p.Options) > 0 {
+		return p.Options[0]
+	}
+	return ""
+}
+
+func multiSelectDefault(p *survey.MultiSelect) []string {
+	switch d := p.Default.(type) {
+	case []string:
+		return d
+	case []int:
+		var values []string
+		for _, i := range d {
+			if i >= 0 && i < len(p.Options) {
+				values = append(values, p.Options[i])
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// option returns the option chosen by its number or text.
+func option(options []string, s string) (core.OptionAnswer, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(options) {
+		return core.OptionAnswer{Value: options[n-1], Index: n - 1}, nil
+	}
+	if i := indexOf(options, s); i >= 0 {
+		return core.OptionAnswer{Value: s, Index: i}, nil
+	}
+	return core.OptionAnswer{}, fmt.Errorf("%q is not one of the options", s)
+}
+
+// plainAnswer converts a line to the answer survey would give for p.
+func plainAnswer(p survey.Prompt, line string) (interface{}, error) {
+	switch p := p.(type) {
+	case *survey.Input:
+		if line = strings.TrimSpace(line); line == "" {
+			return p.Default, nil
+		}
+		return line, nil
+	case *survey.Password:
+		return line, nil
+	case *survey.Confirm:
+		switch strings.ToLower(line) {
+		case "":
+			return p.Default, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		return nil, fmt.Errorf("answer y or n")
+	case *survey.Select:
+		if line == "" {
+			line = selectDefault(p)
+		}
+		return option(p.Options, line)
+	case *survey.MultiSelect:
+		values := multiSelectDefault(p)
+		if line == "-" {
+			values = nil
+		} else if line != "" {
+			values = splitList(line)
+		}
+		answers := []core.OptionAnswer{}
+		for _, v := range values {
+			o, err := option(p.Options, v)
+			if err != nil {
+				return nil, err
+			}
+			answers = append(answers, o)
+		}
+		return answers, nil
+	}
+	return nil, fmt.Errorf("unsupported prompt %T", p)
+}
@@ -0,0 +1,42 @@
+package prompts
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func TestInputAnswersAreTrimmed(t *testing.T) {
+	sc := &Script{Answers: map[string]string{"Namespace:": "  shop \t"}}
+	var namespace string
+	err := sc.Run(func() error {
+		return askOne(&survey.Input{Message: "Namespace:"}, &namespace, survey.WithValidator(ValidateDNSLabel))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespace != "shop" {
+		t.Errorf("answer = %q, want shop", namespace)
+	}
+}
+
+func TestPlainPromptsWriteToOut(t *testing.T) {
+	var out bytes.Buffer
+	prevOut, prevStdin, prevPlain := Out, stdin, Plain
+	Out, stdin, Plain = &out, bufio.NewReader(strings.NewReader("y\n")), true
+	defer func() { Out, stdin, Plain = prevOut, prevStdin, prevPlain }()
+
+	var ok bool
+	if err := askOne(&survey.Confirm{Message: "Write the files?"}, &ok); err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("answer = false, want true")
+	}
+	if !strings.Contains(out.String(), "Write the files?") {
+		t.Errorf("prompt not written to Out: %q", out.String())
+	}
+}
@@ -0,0 +1,219 @@
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// defaultPolicy is the bundled conftest policy. Rules named deny block
+// applying the manifests, rules named warn are reported only.
+const defaultPolicy = `package main
+
+import rego.v1
+
+workloads := {"Deployment", "StatefulSet", "DaemonSet", "Job", "ReplicaSet"}
+
+pod_spec := input.spec.template.spec if input.kind in workloads
+
+pod_spec := input.spec.jobTemplate.spec.template.spec if input.kind == "CronJob"
+
+pod_spec := input.spec if input.kind == "Pod"
+
+containers contains c if {
+	some c in pod_spec.containers
+}
+
+containers contains c if {
+	some c in pod_spec.initContainers
+}
+
+name := sprintf("%s %s", [input.kind, input.metadata.name])
+
+image_tag(image) := "latest" if {
+	parts := split(image, "/")
+	not contains(parts[count(parts) - 1], ":")
+}
+
+image_tag(image) := tag if {
+	parts := split(image, "/")
+	tag := split(parts[count(parts) - 1], ":")[1]
+}
+
+deny contains msg if {
+	some c in containers
+	c.securityContext.privileged
+	msg := sprintf("%s: container %s is privileged", [name, c.name])
+}
+
+deny contains msg if {
+	some c in containers
+	not contains(c.image, "@")
+	image_tag(c.image) == "latest"
+	msg := sprintf("%s: container %s uses the latest tag of %s", [name, c.name, c.image])
+}
+
+deny contains msg if {
+	some v in pod_spec.volumes
+	v.hostPath
+	msg := sprintf("%s: volume %s mounts a host path", [name, v.name])
+}
+
+warn contains msg if {
+	some c in containers
+	not c.resources.limits.memory
+	msg := sprintf("%s: container %s has no memory limit", [name, c.name])
+}
+
+warn contains msg if {
+	some c in containers
+	not c.securityContext.runAsNonRoot
+	not pod_spec.securityContext.runAsNonRoot
+	msg := sprintf("%s: container %s may run as root", [name, c.name])
+}
+`
+
+// Policy severities, after the conftest rule names.
+const (
+	SeverityDeny = "deny"
+	SeverityWarn = "warn"
+)
+
+// PolicyViolation is a policy rule an overlay's build output breaks.
+type PolicyViolation struct {
+	Env      string
+	Severity string
+	Msg      string
+}
+
+// PolicyDir returns the directory user policies are read from besides the
+// ones given on the command line.
+func PolicyDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kustomize_builder", "policies"), nil
+}
+
+// Conftest runs the build output of every overlay through the bundled
+// policy and the Rego policies in dirs.
+func Conftest(rendered map[string][]byte, dirs []string) ([]PolicyViolation, error) {
+	bundled, err := os.MkdirTemp("", "kustomize-builder-policy")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(bundled)
+	if err := os.WriteFile(filepath.Join(bundled, "default.rego"), []byte(defaultPolicy), 0o644); err != nil {
+		return nil, err
+	}
+
+	args := []string{"test", "--output", "json", "--all-namespaces", "--parser", "yaml", "--policy", bundled}
+	for _, dir := range dirs {
+		args = append(args, "--policy", dir)
+	}
+	args = append(args, "-")
+
+	envs := make([]string, 0, len(rendered))
+	for env := range rendered {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	var violations []PolicyViolation
+	for _, env := range envs {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command("conftest", args...)
+		cmd.Stdin = bytes.NewReader(rendered[env])
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		runErr := cmd.Run()
+		var notFound *exec.Error
+		if errors.As(runErr, &notFound) {
+			return nil, runErr
+		}
+
+		found, err := parseConftest(env, stdout.Bytes())
+		if err != nil {
+			if runErr != nil {
+				return nil, fmt.Errorf("conftest: %w: %s", runErr, stderr.String())
+			}
+			return nil, fmt.Errorf("conftest: %w", err)
+		}
+		violations = append(violations, found...)
+	}
+	return violations, nil
+}
+
+// parseConftest returns the violations in the JSON output of conftest for
+// the build output of env, failures denying and warnings warning.
+func parseConftest(env string, out []byte) ([]PolicyViolation, error) {
+	var results []struct {
+		Warnings []struct {
+			Msg string `json:"msg"`
+		} `json:"warnings"`
+		Failures []struct {
+			Msg string `json:"msg"`
+		} `json:"failures"`
+	}
+	if err := json.Unmarshal(out, &results); err != nil {
+		return nil, err
+	}
+	var violations []PolicyViolation
+	for _, r := range results {
+		for _, f := range r.Failures {
+			violations = append(violations, PolicyViolation{Env: env, Severity: SeverityDeny, Msg: f.Msg})
+		}
+		for _, w := range r.Warnings {
+			violations = append(violations, PolicyViolation{Env: env, Severity: SeverityWarn, Msg: w.Msg})
+		}
+	}
+	return violations, nil
+}
+
+// PolicyOptions checks the build output against the policies, with those in
+// PolicyDir and dirs added to the bundled ones, and prints the violations
+// grouped by severity. It reports whether any rule denies the manifests,
+// which blocks applying them. Without conftest the checks are skipped,
+// unless dirs were given or required is set, as when applying without
+// confirmation, which makes it an error.
+func PolicyOptions(rendered map[string][]byte, dirs []string, required bool) (denied bool, err error) {
+	required = required || len(dirs) > 0
+	if dir, err := PolicyDir(); err == nil {
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append([]string{dir}, dirs...)
+		}
+	}
+	violations, err := Conftest(rendered, dirs)
+	var notFound *exec.Error
+	if errors.As(err, &notFound) {
+		if required {
+			return false, fmt.Errorf("conftest not found in PATH, which checking the policies needs")
+		}
+		fmt.Fprintln(Out, "conftest not found in PATH, skipping policy checks")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, severity := range []string{SeverityDeny, SeverityWarn} {
+		var printed bool
+		for _, v := range violations {
+			if v.Severity != severity {
+				continue
+			}
+			if !printed {
+				fmt.Fprintf(Out, "Policy %s:\n", severity)
+				printed = true
+			}
+			fmt.Fprintf(Out, "- %s: %s\n", v.Env, v.Msg)
+			denied = denied || severity == SeverityDeny
+		}
+	}
+	return denied, nil
+}
@@ -0,0 +1,63 @@
+package prompts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConftest(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		out  string
+		want []PolicyViolation
+		err  bool
+	}{
+		{name: "clean", out: `[{"filename": "", "namespace": "main", "successes": 5}]`},
+		{
+			name: "failures and warnings",
+			out: `[
+				{"filename": "", "namespace": "main", "failures": [{"msg": "Deployment shop: container shop is privileged"}], "warnings": [{"msg": "Deployment shop: container shop has no memory limit"}]},
+				{"filename": "", "namespace": "team", "failures": [{"msg": "Service shop: no owner label"}]}
+			]`,
+			want: []PolicyViolation{
+				{Env: "prod", Severity: SeverityDeny, Msg: "Deployment shop: container shop is privileged"},
+				{Env: "prod", Severity: SeverityWarn, Msg: "Deployment shop: container shop has no memory limit"},
+				{Env: "prod", Severity: SeverityDeny, Msg: "Service shop: no owner label"},
+			},
+		},
+		{name: "not json", out: "Error: running test: load: no policies found", err: true},
+	} {
+		got, err := parseConftest("prod", []byte(tc.out))
+		if tc.err {
+			if err == nil {
+				t.Errorf("%s: parsed %+v, want an error", tc.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: violations = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPolicyOptionsWithoutConftest(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	rendered := map[string][]byte{"dev": []byte("kind: ConfigMap\n")}
+	for _, tc := range []struct {
+		name     string
+		dirs     []string
+		required bool
+		fails    bool
+	}{
+		{name: "bundled only", fails: false},
+		{name: "explicit policies", dirs: []string{t.TempDir()}, fails: true},
+		{name: "applying without confirmation", required: true, fails: true},
+	} {
+		denied, err := PolicyOptions(rendered, tc.dirs, tc.required)
+		if (err != nil) != tc.fails || denied {
+			t.Errorf("%s: PolicyOptions = %v, %v, want an error %v", tc.name, denied, err, tc.fails)
+		}
+	}
+}
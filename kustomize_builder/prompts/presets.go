@@ -0,0 +1,248 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Preset pre-answers wizard steps for a common kind of application and
+// scaffolds its base resources.
+type Preset struct {
+	Name         string   `yaml:"name"`
+	Description  string   `yaml:"description"`
+	Environments []string `yaml:"environments,omitempty"`
+	// Namespace defaults to the application name when empty.
+	Namespace  string   `yaml:"namespace,omitempty"`
+	Components []string `yaml:"components,omitempty"`
+	// Resources maps base file names to text/template contents, executed
+	// with the application name as .App.
+	Resources map[string]string `yaml:"resources,omitempty"`
+	// Skip lists the titles of the wizard steps the preset answers.
+	Skip []string `yaml:"skip,omitempty"`
+}
+
+const deploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.App}}
+  labels:
+    app.kubernetes.io/name: {{.App}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{.App}}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{.App}}
+    spec:
+      containers:
+        - name: {{.App}}
+          image: {{.App}}:latest
+          ports:
+            - name: http
+              containerPort: 8080
+`
+
+const serviceTemplate = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{.App}}
+  labels:
+    app.kubernetes.io/name: {{.App}}
+spec:
+  selector:
+    app.kubernetes.io/name: {{.App}}
+  ports:
+    - name: http
+      port: 80
+      targetPort: http
+`
+
+const cronJobTemplate = `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: {{.App}}
+  labels:
+    app.kubernetes.io/name: {{.App}}
+spec:
+  schedule: "0 * * * *"
+  concurrencyPolicy: Forbid
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          labels:
+            app.kubernetes.io/name: {{.App}}
+        spec:
+          restartPolicy: OnFailure
+          containers:
+            - name: {{.App}}
+              image: {{.App}}:latest
+`
+
+// builtinPresets ship with the builder. Presets in the user config directory
+// with the same name take precedence.
+var builtinPresets = []Preset{
+	{
+		Name:         "public-web-service",
+		Description:  "Deployment and Service exposed through the public Istio gateway",
+		Environments: []string{"dev", "staging", "prod"},
+		Components:   []string{"istio-ingress", "pdb", "monitoring"},
+		Resources:    map[string]string{"deployment.yaml": deploymentTemplate, "service.yaml": serviceTemplate},
+		Skip:         []string{"Resources", "Helm charts"},
+	},
+	{
+		Name:         "internal-api",
+		Description:  "Deployment and Service reachable inside the cluster only",
+		Environments: []string{"dev", "staging", "prod"},
+		Components:   []string{"pdb", "monitoring"},
+		Resources:    map[string]string{"deployment.yaml": deploymentTemplate, "service.yaml": serviceTemplate},
+		Skip:         []string{"Resources", "Helm charts"},
+	},
+	{
+		Name:         "cronjob",
+		Description:  "Scheduled CronJob without a Service",
+		Environments: []string{"dev", "prod"},
+		Resources:    map[string]string{"cronjob.yaml": cronJobTemplate},
+		Skip:         []string{"Resources", "Helm charts", "Sizing"},
+	},
+}
+
+// PresetDir returns the directory custom presets are read from.
+func PresetDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kustomize_builder", "presets"), nil
+}
+
+// Presets returns the built-in presets and those defined as YAML files in
+// PresetDir, sorted by name.
+func Presets() ([]Preset, error) {
+	byName := map[string]Preset{}
+	for _, p := range builtinPresets {
+		byName[p.Name] = p
+	}
+
+	dir, err := PresetDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var p Preset
+		if err := yaml.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if p.Name == "" {
+			p.Name = strings.TrimSuffix(filepath.Base(path), ".yaml")
+		}
+		byName[p.Name] = p
+	}
+
+	presets := make([]Preset, 0, len(byName))
+	for _, p := range byName {
+		presets = append(presets, p)
+	}
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Name < presets[j].Name })
+	return presets, nil
+}
+
+// FindPreset returns the preset with the given name.
+func FindPreset(name string) (Preset, error) {
+	presets, err := Presets()
+	if err != nil {
+		return Preset{}, err
+	}
+	for _, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Preset{}, fmt.Errorf("unknown preset %q", name)
+}
+
+// Apply pre-answers the wizard with the preset. The answers become the
+// defaults of the remaining prompts, and the steps it lists in Skip are not
+// asked at all. Environments already answered, e.g. by an answers file, are
+// kept.
+func (p Preset) Apply(s *State) error {
+	l := s.Layout
+	if len(p.Environments) > 0 && !s.Skip["Environments"] {
+		l.SetEnvs(p.Environments)
+	}
+	l.Base.Namespace = p.Namespace
+	if l.Base.Namespace == "" {
+		l.Base.Namespace = l.App
+	}
+
+	names := make([]string, 0, len(p.Resources))
+	for name := range p.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t, err := template.New(name).Parse(p.Resources[name])
+		if err != nil {
+			return fmt.Errorf("preset %s: %w", p.Name, err)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, struct{ App string }{l.App}); err != nil {
+			return fmt.Errorf("preset %s: %w", p.Name, err)
+		}
+		l.AddFiles(BaseDir, File{Path: name, Content: buf.Bytes()})
+		l.Base.AddResource(name)
+	}
+
+	for _, env := range l.Envs {
+		for _, c := range p.Components {
+			path := "../../" + ComponentDir(c)
+			if !containsString(l.Overlays[env].Components, path) {
+				l.Overlays[env].Components = append(l.Overlays[env].Components, path)
+			}
+		}
+	}
+
+	if s.Skip == nil {
+		s.Skip = map[string]bool{}
+	}
+	for _, title := range p.Skip {
+		s.Skip[title] = true
+	}
+	return nil
+}
+
+// PresetOptions asks for a preset and applies it.
+func PresetOptions(s *State) error {
+	presets, err := Presets()
+	if err != nil {
+		return err
+	}
+	options := []string{"none"}
+	for _, p := range presets {
+		options = append(options, p.Name+" - "+p.Description)
+	}
+	var i int
+	if err := askOne(&survey.Select{Message: "Start from a preset:", Options: options}, &i); err != nil || i == 0 {
+		return err
+	}
+	return presets[i-1].Apply(s)
+}
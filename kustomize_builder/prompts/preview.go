@@ -0,0 +1,198 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/alecthomas/chroma/v2/quick"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// localPaths returns the files k references, relative to its directory.
+func (k *Kustomization) localPaths() []string {
+	var paths []string
+	paths = append(paths, k.Resources...)
+	paths = append(paths, k.Bases...)
+	for _, g := range k.ConfigMapGenerator {
+		paths = append(paths, g.Envs...)
+		paths = append(paths, fileSourcePaths(g.Files)...)
+	}
+	for _, g := range k.SecretGenerator {
+		paths = append(paths, g.Envs...)
+		paths = append(paths, fileSourcePaths(g.Files)...)
+	}
+	for _, c := range k.HelmCharts {
+		if c.ValuesFile != "" {
+			paths = append(paths, c.ValuesFile)
+		}
+	}
+	for _, p := range k.Patches {
+		if p.Path != "" {
+			paths = append(paths, p.Path)
+		}
+	}
+	paths = append(paths, k.PatchesStrategicMerge...)
+	for _, p := range k.PatchesJSON6902 {
+		paths = append(paths, p.Path)
+	}
+
+	local := paths[:0]
+	for _, p := range paths {
+		if !strings.Contains(p, "://") {
+			local = append(local, p)
+		}
+	}
+	return local
+}
+
+func fileSourcePaths(sources []string) []string {
+	paths := make([]string, 0, len(sources))
+	for _, s := range sources {
+		if _, path, ok := strings.Cut(s, "="); ok {
+			s = path
+		}
+		paths = append(paths, s)
+	}
+	return paths
+}
+
+// Build runs kustomize build in dir of the layout as if it were written to
+// outDir, without touching outDir. Files the layout references that were not
+// generated are read from disk.
+func Build(outDir string, l *Layout, dir string) ([]byte, error) {
+	root, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := l.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	for _, f := range files {
+		if err := fSys.WriteFile(filepath.Join(root, filepath.FromSlash(f.Path)), f.Content); err != nil {
+			return nil, err
+		}
+	}
+	helm := false
+	for kdir, k := range l.Kustomizations() {
+		helm = helm || len(k.HelmCharts) > 0
+		for _, p := range k.localPaths() {
+			if err := copyToFs(fSys, filepath.Join(root, filepath.FromSlash(kdir), filepath.FromSlash(p))); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	opts := krusty.MakeDefaultOptions()
+	opts.LoadRestrictions = types.LoadRestrictionsNone
+	if helm {
+		opts.PluginConfig.HelmConfig.Enabled = true
+		opts.PluginConfig.HelmConfig.Command = "helm"
+	}
+	resMap, err := krusty.MakeKustomizer(opts).Run(fSys, filepath.Join(root, filepath.FromSlash(dir)))
+	if err != nil {
+		return nil, err
+	}
+	return resMap.AsYaml()
+}
+
+// BuildOverlays builds the overlay of every environment.
+func BuildOverlays(outDir string, l *Layout) (map[string][]byte, error) {
+	rendered := map[string][]byte{}
+	for _, env := range l.Envs {
+		out, err := Build(outDir, l, OverlayDir(env))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", OverlayDir(env), err)
+		}
+		rendered[env] = out
+	}
+	return rendered, nil
+}
+
+// copyToFs copies the file or directory at path from disk into fSys, leaving
+// files that already exist in fSys alone. Missing paths are ignored.
+func copyToFs(fSys filesys.FileSystem, path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return fSys.MkdirAll(p)
+		}
+		if fSys.Exists(p) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return fSys.WriteFile(p, data)
+	})
+}
+
+// PreviewOptions shows the rendered overlays in a pager and asks whether to
+// write the files.
+func PreviewOptions(l *Layout, rendered map[string][]byte) (bool, error) {
+	var show bool
+	err := askOne(&survey.Confirm{Message: "Preview the kustomize build output?", Default: true}, &show)
+	if err != nil {
+		return false, err
+	}
+	if show {
+		var all bytes.Buffer
+		for _, env := range l.Envs {
+			fmt.Fprintf(&all, "# %s\n", OverlayDir(env))
+			all.Write(rendered[env])
+		}
+		if err := page(all.Bytes()); err != nil {
+			return false, err
+		}
+	}
+	var write bool
+	err = askOne(&survey.Confirm{Message: "Write the files?", Default: true}, &write)
+	return write, err
+}
+
+// page shows highlighted YAML through $PAGER, falling back to Out.
+func page(yaml []byte) error {
+	if Plain {
+		_, err := Out.Write(yaml)
+		return err
+	}
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, string(yaml), "yaml", "terminal256", "monokai"); err != nil {
+		buf.Reset()
+		buf.Write(yaml)
+	}
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -R"
+	}
+	fields := strings.Fields(pager)
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stdin = &buf
+	cmd.Stdout = Out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.Error); !ok {
+			return fmt.Errorf("pager: %w", err)
+		}
+		_, err = Out.Write(buf.Bytes())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package prompts
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+)
+
+// PromptModule is a self-contained group of options, such as Istio routing or
+// cert-manager certificates. Modules register themselves with Register and
+// each becomes a step of the wizard.
+type PromptModule interface {
+	// Name is the module's wizard step title.
+	Name() string
+	// Questions are asked when the module's step runs.
+	Questions() []*survey.Question
+	// Generate returns the module's files, relative to the output directory,
+	// for the given answers. It may register them in answers.Layout.
+	Generate(answers Answers) ([]File, error)
+}
+
+// ConditionalModule is implemented by modules that only apply to some
+// layouts.
+type ConditionalModule interface {
+	PromptModule
+	Applies(l *Layout) bool
+}
+
+// OptionalModule is implemented by modules the user opts into. Their
+// questions are only asked when the OptIn confirmation is answered yes.
+type OptionalModule interface {
+	PromptModule
+	OptIn() string
+}
+
+// FollowUpModule is implemented by modules with questions that only follow
+// from some answers to their earlier questions.
+type FollowUpModule interface {
+	PromptModule
+	// Asks reports whether the named question is asked, given the answers
+	// to the questions before it.
+	Asks(name string, answers Answers) bool
+}
+
+// Answers are the answers to a module's questions by question name, along
+// with the layout the earlier steps built.
+type Answers struct {
+	Layout *Layout
+	Values map[string]interface{}
+	// BaseDir is the base directory on disk, which the base resources that
+	// were not generated are read from.
+	BaseDir string
+}
+
+// String returns the answer to an Input or Select question.
+func (a Answers) String(name string) string {
+	switch v := a.Values[name].(type) {
+	case string:
+		return v
+	case core.OptionAnswer:
+		return v.Value
+	}
+	return ""
+}
+
+// Strings returns the answers to a MultiSelect question.
+func (a Answers) Strings(name string) []string {
+	switch v := a.Values[name].(type) {
+	case []string:
+		return v
+	case []core.OptionAnswer:
+		values := make([]string, len(v))
+		for i, o := range v {
+			values[i] = o.Value
+		}
+		return values
+	}
+	return nil
+}
+
+// Bool returns the answer to a Confirm question.
+func (a Answers) Bool(name string) bool {
+	v, _ := a.Values[name].(bool)
+	return v
+}
+
+var modules []PromptModule
+
+// Register adds a module to the wizard. It is meant to be called from init
+// functions and panics if a module with the same name is registered twice.
+func Register(m PromptModule) {
+	for _, existing := range modules {
+		if existing.Name() == m.Name() {
+			panic(fmt.Sprintf("prompts: module %q registered twice", m.Name()))
+		}
+	}
+	modules = append(modules, m)
+}
+
+// Modules returns the registered modules in registration order.
+func Modules() []PromptModule {
+	return append([]PromptModule(nil), modules...)
+}
+
+// ModuleStep returns the wizard step running m.
+func ModuleStep(m PromptModule) Step {
+	step := Step{
+		Title: m.Name(),
+		Run: func(s *State) error {
+			answers := Answers{Layout: s.Layout, Values: map[string]interface{}{}, BaseDir: s.BaseDir()}
+			if values, ok := s.Answers.module(m.Name()); ok {
+				answers.Values = values
+			} else {
+				if o, ok := m.(OptionalModule); ok {
+					var enable bool
+					if err := askOne(&survey.Confirm{Message: o.OptIn()}, &enable); err != nil || !enable {
+						return err
+					}
+				}
+				if err := askQuestions(m, answers); err != nil {
+					return err
+				}
+			}
+			s.given(m, answers.Values)
+			files, err := generate(s, m, answers)
+			if err != nil {
+				return err
+			}
+			s.Layout.Files = append(s.Layout.Files, files...)
+			return nil
+		},
+	}
+	if c, ok := m.(ConditionalModule); ok {
+		step.When = func(s *State) bool { return c.Applies(s.Layout) }
+	}
+	return step
+}
+
+// askQuestions asks the questions of m into answers, leaving out the
+// follow-up questions that do not follow from the earlier answers.
+func askQuestions(m PromptModule, answers Answers) error {
+	qs := m.Questions()
+	f, ok := m.(FollowUpModule)
+	if !ok {
+		if len(qs) == 0 {
+			return nil
+		}
+		return ask(qs, &answers.Values)
+	}
+	for _, q := range qs {
+		if !f.Asks(q.Name, answers) {
+			continue
+		}
+		if err := ask([]*survey.Question{q}, &answers.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generate runs m.Generate, recording in s the answers to the prompts it
+// asks.
+func generate(s *State, m PromptModule, answers Answers) ([]File, error) {
+	prev := recorded
+	recorded = map[string]string{}
+	defer func() { recorded = prev }()
+	files, err := m.Generate(answers)
+	for message, answer := range recorded {
+		if s.Given.Prompts == nil {
+			s.Given.Prompts = map[string]string{}
+		}
+		s.Given.Prompts[message] = answer
+	}
+	return files, err
+}
@@ -0,0 +1,195 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// policyRule is an RBAC rule.
+type policyRule struct {
+	APIGroups []string `yaml:"apiGroups"`
+	Resources []string `yaml:"resources"`
+	Verbs     []string `yaml:"verbs"`
+}
+
+var readVerbs = []string{"get", "list", "watch"}
+
+// permissions are the common API permissions offered for selection.
+var permissions = map[string]policyRule{
+	"Read ConfigMaps":               {[]string{""}, []string{"configmaps"}, readVerbs},
+	"Read Secrets":                  {[]string{""}, []string{"secrets"}, readVerbs},
+	"Read Pods":                     {[]string{""}, []string{"pods"}, readVerbs},
+	"Read Services and Endpoints":   {[]string{""}, []string{"services", "endpoints"}, readVerbs},
+	"Create Events":                 {[]string{""}, []string{"events"}, []string{"create", "patch"}},
+	"Leader election (Leases)":      {[]string{"coordination.k8s.io"}, []string{"leases"}, []string{"get", "create", "update"}},
+	"Manage Jobs":                   {[]string{"batch"}, []string{"jobs"}, []string{"get", "list", "watch", "create", "delete"}},
+	"Read Deployments/StatefulSets": {[]string{"apps"}, []string{"deployments", "statefulsets"}, readVerbs},
+}
+
+// builtinGroups are the API groups without a dot, which a rule naming the
+// group first, as in apps/deployments, would pass off as a resource.
+var builtinGroups = []string{"apps", "autoscaling", "batch", "extensions", "policy"}
+
+// parseRule parses a rule such as deployments.apps:get,list or pods/log:get,
+// the resource being in the core group when it has no group suffix.
+func parseRule(s string) (policyRule, error) {
+	resource, verbs, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || resource == "" || verbs == "" {
+		return policyRule{}, fmt.Errorf("rule %q must be of the form resource[/subresource][.group]:verb,verb", s)
+	}
+	resource, group, _ := strings.Cut(resource, ".")
+	parent, _, _ := strings.Cut(resource, "/")
+	if strings.Contains(group, "/") || strings.Count(resource, "/") > 1 ||
+		(parent != resource && containsString(builtinGroups, parent)) {
+		return policyRule{}, fmt.Errorf("rule %q must name the group after the resource, e.g. deployments.apps or deployments/scale.apps", s)
+	}
+	return policyRule{APIGroups: []string{group}, Resources: []string{resource}, Verbs: splitList(verbs)}, nil
+}
+
+func validateRules(ans interface{}) error {
+	for _, s := range strings.Split(answer(ans), ";") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		if _, err := parseRule(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rbacModule gives the app's workloads a ServiceAccount of their own, bound
+// to a Role or ClusterRole with the permissions they need.
+type rbacModule struct{}
+
+func (rbacModule) Name() string { return "Service account" }
+
+func (rbacModule) OptIn() string { return "Run the app with a dedicated ServiceAccount?" }
+
+func (rbacModule) Questions() []*survey.Question {
+	var options []string
+	for name := range permissions {
+		options = append(options, name)
+	}
+	sort.Strings(options)
+	return []*survey.Question{
+		{
+			Name:     "name",
+			Prompt:   &survey.Input{Message: "ServiceAccount name (optional):", Help: "Defaults to the application name."},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+		{
+			Name:   "permissions",
+			Prompt: &survey.MultiSelect{Message: "API permissions:", Options: options},
+		},
+		{
+			Name: "rules",
+			Prompt: &survey.Input{
+				Message: "Other rules, separated by ; (optional):",
+				Help:    "E.g. deployments.apps:get,list;pods/log:get;deployments/scale.apps:update",
+			},
+			Validate: validateRules,
+		},
+		{
+			Name: "clusterWide",
+			Prompt: &survey.Confirm{
+				Message: "Grant the permissions cluster-wide?",
+				Help:    "Generates a ClusterRole and ClusterRoleBinding instead of a Role and RoleBinding.",
+			},
+		},
+	}
+}
+
+func (rbacModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	name := answers.String("name")
+	if name == "" {
+		name = l.App
+	}
+	var rules []policyRule
+	for _, p := range answers.Strings("permissions") {
+		rules = append(rules, permissions[p])
+	}
+	for _, s := range strings.Split(answers.String("rules"), ";") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		rule, err := parseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	var files []File
+	add := func(file string, obj interface{}) error {
+		content, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, file), Content: content})
+		return nil
+	}
+	err := add("serviceaccount.yaml", map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ServiceAccount",
+		"metadata":   map[string]interface{}{"name": name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.Base.AddResource("serviceaccount.yaml")
+
+	if len(rules) > 0 {
+		role, binding := "Role", "RoleBinding"
+		if answers.Bool("clusterWide") {
+			role, binding = "ClusterRole", "ClusterRoleBinding"
+		}
+		// The namespace transformer rewrites the subject along with the
+		// ServiceAccount when the overlays change the namespace.
+		namespace := l.Base.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		err := add("role.yaml", map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       role,
+			"metadata":   map[string]interface{}{"name": name},
+			"rules":      rules,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = add("rolebinding.yaml", map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       binding,
+			"metadata":   map[string]interface{}{"name": name},
+			"roleRef":    map[string]interface{}{"apiGroup": "rbac.authorization.k8s.io", "kind": role, "name": name},
+			"subjects": []interface{}{
+				map[string]interface{}{"kind": "ServiceAccount", "name": name, "namespace": namespace},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		l.Base.AddResource("role.yaml")
+		l.Base.AddResource("rolebinding.yaml")
+	}
+
+	kinds, err := workloadKinds(l, answers.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, kind := range kinds {
+		file := "patches/service-account-" + strings.ToLower(kind) + ".yaml"
+		if err := add(file, podSpecPatch(kind, map[string]interface{}{"serviceAccountName": name})); err != nil {
+			return nil, err
+		}
+		l.Base.AddPatch(Patch{Path: file, Target: &Selector{Kind: kind}})
+	}
+	return files, nil
+}
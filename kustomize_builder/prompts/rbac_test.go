@@ -0,0 +1,60 @@
+package prompts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRule(t *testing.T) {
+	for _, tc := range []struct {
+		rule string
+		want policyRule
+		err  bool
+	}{
+		{rule: "pods:get", want: policyRule{[]string{""}, []string{"pods"}, []string{"get"}}},
+		{rule: "deployments.apps:get,list", want: policyRule{[]string{"apps"}, []string{"deployments"}, []string{"get", "list"}}},
+		{rule: "pods/log:get", want: policyRule{[]string{""}, []string{"pods/log"}, []string{"get"}}},
+		{rule: "deployments/scale.apps:update", want: policyRule{[]string{"apps"}, []string{"deployments/scale"}, []string{"update"}}},
+		{rule: "ingresses.networking.k8s.io:get", want: policyRule{[]string{"networking.k8s.io"}, []string{"ingresses"}, []string{"get"}}},
+		{rule: "apps/deployments:get", err: true},
+		{rule: "networking.k8s.io/ingresses:get", err: true},
+		{rule: "deployments.apps/scale:update", err: true},
+		{rule: "pods", err: true},
+		{rule: ":get", err: true},
+	} {
+		got, err := parseRule(tc.rule)
+		if tc.err {
+			if err == nil {
+				t.Errorf("parseRule(%q) = %+v, want an error", tc.rule, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseRule(%q): %v", tc.rule, err)
+		} else if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseRule(%q) = %+v, want %+v", tc.rule, got, tc.want)
+		}
+	}
+}
+
+func TestServiceAccountPatchesEveryWorkloadKind(t *testing.T) {
+	l := NewLayout("shop", []string{"dev"})
+	l.Files = []File{
+		{Path: "base/statefulset.yaml", Content: []byte("apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: shop\n")},
+		{Path: "base/cronjob.yaml", Content: []byte("apiVersion: batch/v1\nkind: CronJob\nmetadata:\n  name: shop-report\n")},
+	}
+	l.Base.AddResource("statefulset.yaml")
+	l.Base.AddResource("cronjob.yaml")
+
+	_, err := rbacModule{}.Generate(Answers{Layout: l, Values: map[string]interface{}{}, BaseDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var targets []string
+	for _, p := range l.Base.Patches {
+		targets = append(targets, p.Target.Kind)
+	}
+	if want := []string{KindStatefulSet, KindCronJob}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("patch targets = %v, want %v", targets, want)
+	}
+}
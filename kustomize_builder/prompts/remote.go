@@ -0,0 +1,247 @@
+package prompts
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+var commitHash = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// remoteBase is a kustomize remote resource such as
+// https://github.com/org/repo//deploy/base?ref=v1.2.0.
+type remoteBase struct {
+	// Repo is the git repository, or the URL of a file fetched over HTTP.
+	Repo string
+	// Path is the directory in the repository, without the leading //.
+	Path  string
+	Ref   string
+	Query url.Values
+}
+
+// isRemote reports whether a resources entry is a URL rather than a local
+// path.
+func isRemote(resource string) bool {
+	return strings.Contains(resource, "://") || strings.HasPrefix(resource, "git@") || strings.HasPrefix(resource, "github.com/")
+}
+
+// isFile reports whether r is a single file fetched over HTTP, which has no
+// ref to pin.
+func (r remoteBase) isFile() bool {
+	if !strings.HasPrefix(r.Repo, "http://") && !strings.HasPrefix(r.Repo, "https://") {
+		return false
+	}
+	for _, ext := range []string{".yaml", ".yml", ".json"} {
+		if strings.HasSuffix(r.Repo, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRemoteBase(s string) (remoteBase, error) {
+	if !isRemote(s) {
+		return remoteBase{}, fmt.Errorf("%q is not a git or HTTP URL", s)
+	}
+	r := remoteBase{Repo: s, Query: url.Values{}}
+	if i := strings.Index(s, "?"); i >= 0 {
+		q, err := url.ParseQuery(s[i+1:])
+		if err != nil {
+			return remoteBase{}, fmt.Errorf("%q: %v", s, err)
+		}
+		r.Repo, r.Query = s[:i], q
+		r.Ref = q.Get("ref")
+		if r.Ref == "" {
+			r.Ref = q.Get("version")
+		}
+		q.Del("ref")
+		q.Del("version")
+	}
+	start := 0
+	if i := strings.Index(r.Repo, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	if i := strings.Index(r.Repo[start:], "//"); i >= 0 {
+		r.Repo, r.Path = r.Repo[:start+i], r.Repo[start+i+2:]
+	} else if i := strings.Index(r.Repo, ".git/"); i >= 0 {
+		r.Repo, r.Path = r.Repo[:i+len(".git")], r.Repo[i+len(".git/"):]
+	}
+	return r, nil
+}
+
+func (r remoteBase) String() string {
+	s := r.Repo
+	if r.Path != "" {
+		s += "//" + r.Path
+	}
+	q := url.Values{}
+	for k, v := range r.Query {
+		q[k] = v
+	}
+	if r.Ref != "" {
+		q.Set("ref", r.Ref)
+	}
+	if len(q) > 0 {
+		s += "?" + q.Encode()
+	}
+	return s
+}
+
+// cloneURL is the URL git fetches r from.
+func (r remoteBase) cloneURL() string {
+	if strings.Contains(r.Repo, "://") || strings.HasPrefix(r.Repo, "git@") {
+		return r.Repo
+	}
+	return "https://" + r.Repo
+}
+
+// remoteRefs returns the commits of the branches and tags of r by ref name,
+// such as refs/tags/v1.2.0. Peeled tags are listed under their tag name.
+func remoteRefs(r remoteBase) (map[string]string, error) {
+	cmd := exec.Command("git", "ls-remote", r.cloneURL())
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("%s does not resolve: %s", r.cloneURL(), strings.TrimSpace(string(exit.Stderr)))
+		}
+		return nil, err
+	}
+	refs := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		commit, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if strings.HasSuffix(name, "^{}") {
+			name = strings.TrimSuffix(name, "^{}")
+		} else if _, peeled := refs[name]; peeled {
+			continue
+		}
+		refs[name] = commit
+	}
+	return refs, nil
+}
+
+// validateRemoteBase accepts remote bases that resolve: git repositories
+// with the given ref, and HTTP files that can be fetched.
+func validateRemoteBase(ans interface{}) error {
+	r, err := parseRemoteBase(answer(ans))
+	if err != nil {
+		return err
+	}
+	if r.isFile() {
+		resp, err := http.Head(r.Repo)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("%s: %s", r.Repo, resp.Status)
+		}
+		return nil
+	}
+	refs, err := remoteRefs(r)
+	if err != nil {
+		return err
+	}
+	if r.Ref != "" && !commitHash.MatchString(r.Ref) && refs["refs/tags/"+r.Ref] == "" && refs["refs/heads/"+r.Ref] == "" {
+		return fmt.Errorf("%s has no branch or tag %q", r.cloneURL(), r.Ref)
+	}
+	return nil
+}
+
+// pinRemoteBase returns the resources entry for the remote base s. Refs
+// that are not a tag or commit move with every push, so when s has one the
+// user is warned and asked for a tag or commit, defaulting to the commit
+// the ref is at now.
+func pinRemoteBase(s string) (string, error) {
+	r, err := parseRemoteBase(s)
+	if err != nil {
+		return "", err
+	}
+	if r.isFile() {
+		note("Warning:", r.Repo, "is fetched over HTTP and cannot be pinned; make sure the URL names a fixed version.")
+		return s, nil
+	}
+	refs, err := remoteRefs(r)
+	if err != nil {
+		return "", err
+	}
+	if commitHash.MatchString(r.Ref) || refs["refs/tags/"+r.Ref] != "" {
+		return r.String(), nil
+	}
+
+	current := refs["HEAD"]
+	if r.Ref == "" {
+		note("Warning:", s, "has no ref, so it follows the default branch.")
+	} else {
+		current = refs["refs/heads/"+r.Ref]
+		note("Warning:", s, "is pinned to branch", r.Ref, "rather than a tag or commit, so it changes with every push.")
+	}
+	var tags []string
+	for name, commit := range refs {
+		if tag := strings.TrimPrefix(name, "refs/tags/"); tag != name && commit == current {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) > 0 {
+		sort.Strings(tags)
+		current = tags[len(tags)-1]
+	}
+	err = askOne(&survey.Input{
+		Message: "Pin to tag or commit:",
+		Default: current,
+		Help:    "Entering a branch keeps the base unpinned.",
+	}, &r.Ref, survey.WithValidator(survey.Required), survey.WithValidator(func(ans interface{}) error {
+		ref := answer(ans)
+		if commitHash.MatchString(ref) || refs["refs/tags/"+ref] != "" || refs["refs/heads/"+ref] != "" {
+			return nil
+		}
+		return fmt.Errorf("%s has no tag or branch %q", r.cloneURL(), ref)
+	}))
+	return r.String(), err
+}
+
+// RemoteBaseOptions asks for remote bases, git or HTTP kustomize URLs, and
+// adds them to k's resources pinned to a tag or commit.
+func RemoteBaseOptions(k *Kustomization) error {
+	var remote []string
+	for _, r := range k.Resources {
+		if isRemote(r) {
+			remote = append(remote, r)
+		}
+	}
+	keep, err := keepEntries("Keep remote bases:", remote, func(s string) string { return s })
+	if err != nil {
+		return err
+	}
+	for _, r := range remote {
+		if !containsString(keep, r) {
+			k.RemoveResource(r)
+		}
+	}
+
+	for {
+		var s string
+		err := askOne(&survey.Input{
+			Message: "Remote base URL (empty to finish):",
+			Help:    "A git or HTTP kustomize URL, e.g. https://github.com/org/repo//deploy/base?ref=v1.2.0.",
+		}, &s, survey.WithValidator(Optional(validateRemoteBase)))
+		if err != nil || strings.TrimSpace(s) == "" {
+			return err
+		}
+		pinned, err := pinRemoteBase(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		k.AddResource(pinned)
+	}
+}
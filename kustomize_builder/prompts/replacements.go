@@ -0,0 +1,369 @@
+package prompts
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Replacement copies the value of a source field into fields of other
+// resources, replacing the deprecated kustomize vars.
+type Replacement struct {
+	Source  *ReplacementSource  `yaml:"source"`
+	Targets []ReplacementTarget `yaml:"targets"`
+}
+
+// ReplacementSource is the field a replacement copies.
+type ReplacementSource struct {
+	Group     string `yaml:"group,omitempty"`
+	Version   string `yaml:"version,omitempty"`
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace,omitempty"`
+	FieldPath string `yaml:"fieldPath,omitempty"`
+}
+
+// ReplacementTarget lists the fields a replacement writes in the resources
+// Select matches.
+type ReplacementTarget struct {
+	Select     *Selector     `yaml:"select"`
+	FieldPaths []string      `yaml:"fieldPaths"`
+	Options    *FieldOptions `yaml:"options,omitempty"`
+}
+
+// FieldOptions replace part of a value, the one at Index when the value is
+// split at Delimiter.
+type FieldOptions struct {
+	Delimiter string `yaml:"delimiter,omitempty"`
+	Index     int    `yaml:"index,omitempty"`
+	Create    bool   `yaml:"create,omitempty"`
+}
+
+func (r Replacement) String() string {
+	var targets []string
+	for _, t := range r.Targets {
+		targets = append(targets, t.Select.Kind+" "+t.Select.Name)
+	}
+	return fmt.Sprintf("%s %s %s -> %s", r.Source.Kind, r.Source.Name, r.Source.FieldPath, strings.Join(targets, ", "))
+}
+
+// resourceObject is a resource of the kustomization with its fields.
+type resourceObject struct {
+	ID     ResourceID
+	Fields map[string]interface{}
+}
+
+// readResourceObjects returns the resources of k read from dir or taken
+// from the generated files below it, and the ConfigMaps its generators
+// create with the keys of their literals and env files.
+func readResourceObjects(dir string, k *Kustomization, generated []File) ([]resourceObject, error) {
+	var objects []resourceObject
+	for _, r := range k.Resources {
+		content, ok, err := readResource(dir, r, generated)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		found, err := readManifestObjects(r, content)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, found...)
+	}
+
+	for _, args := range k.ConfigMapGenerator {
+		data := map[string]interface{}{}
+		for _, literal := range args.Literals {
+			if key, value, ok := strings.Cut(literal, "="); ok {
+				data[key] = value
+			}
+		}
+		for _, env := range args.Envs {
+			content, ok, err := readResource(dir, env, generated)
+			if err != nil || !ok {
+				continue
+			}
+			scanner := bufio.NewScanner(bytes.NewReader(content))
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if key, value, ok := strings.Cut(line, "="); ok && !strings.HasPrefix(line, "#") {
+					data[key] = value
+				}
+			}
+		}
+		objects = append(objects, resourceObject{
+			ID: ResourceID{Version: "v1", Kind: "ConfigMap", Name: args.Name},
+			Fields: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": args.Name},
+				"data":       data,
+			},
+		})
+	}
+	return objects, nil
+}
+
+// readManifestObjects decodes the objects of the manifest at path.
+func readManifestObjects(path string, content []byte) ([]resourceObject, error) {
+	var objects []resourceObject
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var n yaml.Node
+		err := dec.Decode(&n)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		var h objectHeader
+		var fields map[string]interface{}
+		if err := n.Decode(&h); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if err := n.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if h.Kind != "" {
+			objects = append(objects, resourceObject{ID: idFromHeader(h), Fields: fields})
+		}
+	}
+}
+
+// splitFieldPath splits a kustomize field path such as
+// spec.template.spec.containers.[name=app].image at the dots outside of
+// list item selectors.
+func splitFieldPath(path string) []string {
+	var parts []string
+	var depth, start int
+	for i, r := range path {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				parts = append(parts, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, path[start:])
+}
+
+// lookupField returns the value at a kustomize field path, and whether it
+// exists. List items are selected by [key=value] or by their index.
+func lookupField(v interface{}, path string) (interface{}, bool) {
+	for _, part := range splitFieldPath(path) {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			child, ok := node[part]
+			if !ok {
+				return nil, false
+			}
+			v = child
+		case []interface{}:
+			if key, value, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(part, "["), "]"), "="); ok && strings.HasPrefix(part, "[") {
+				var found bool
+				for _, item := range node {
+					if m, ok := item.(map[string]interface{}); ok && fmt.Sprint(m[key]) == value {
+						v, found = item, true
+						break
+					}
+				}
+				if !found {
+					return nil, false
+				}
+				continue
+			}
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// leafPaths returns the field paths of the scalar values of v, selecting
+// list items by name. Items without a name are left out.
+func leafPaths(v interface{}, prefix string) []string {
+	var paths []string
+	switch node := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if strings.ContainsAny(key, ".[]") {
+				continue
+			}
+			paths = append(paths, leafPaths(node[key], joinFieldPath(prefix, key))...)
+		}
+	case []interface{}:
+		for _, item := range node {
+			if m, ok := item.(map[string]interface{}); ok {
+				if name, ok := m["name"].(string); ok {
+					paths = append(paths, leafPaths(item, joinFieldPath(prefix, "[name="+name+"]"))...)
+				}
+			}
+		}
+	case nil:
+	default:
+		paths = append(paths, prefix)
+	}
+	return paths
+}
+
+func joinFieldPath(prefix, part string) string {
+	if prefix == "" {
+		return part
+	}
+	return prefix + "." + part
+}
+
+// validateFieldOf accepts field paths that exist in o.
+func validateFieldOf(o resourceObject) survey.Validator {
+	return func(ans interface{}) error {
+		path := answer(ans)
+		if _, ok := lookupField(o.Fields, path); !ok {
+			return fmt.Errorf("%s %s has no field %s", o.ID.Kind, o.ID.Name, path)
+		}
+		return nil
+	}
+}
+
+// ReplacementOptions asks for replacements copying a field of one resource
+// of the base of l, read from baseDir or generated, into fields of others.
+// Only fields that exist in the selected resources can be picked.
+func ReplacementOptions(l *Layout, baseDir string) error {
+	k := l.Base
+	objects, err := readResourceObjects(baseDir, k, l.Generated(BaseDir))
+	if err != nil {
+		return err
+	}
+	k.Replacements, err = keepEntries("Keep replacements:", k.Replacements, Replacement.String)
+	if err != nil || len(objects) == 0 {
+		return err
+	}
+
+	more, err := askMore("Add a replacement?")
+	for ; err == nil && more; more, err = askMore("Add another replacement?") {
+		r, err := askReplacement(objects)
+		if err != nil {
+			return err
+		}
+		if len(r.Targets) > 0 {
+			k.Replacements = append(k.Replacements, r)
+		}
+	}
+	return err
+}
+
+func askReplacement(objects []resourceObject) (Replacement, error) {
+	names := make([]string, len(objects))
+	for i, o := range objects {
+		names[i] = o.ID.String()
+	}
+	var i int
+	if err := askOne(&survey.Select{Message: "Replacement source:", Options: names}, &i); err != nil {
+		return Replacement{}, err
+	}
+	src := objects[i]
+	var field string
+	err := askOne(&survey.Select{
+		Message: "Source field:",
+		Options: leafPaths(src.Fields, ""),
+		Default: "metadata.name",
+	}, &field)
+	if err != nil {
+		return Replacement{}, err
+	}
+	r := Replacement{Source: &ReplacementSource{
+		Group:     src.ID.Group,
+		Version:   src.ID.Version,
+		Kind:      src.ID.Kind,
+		Name:      src.ID.Name,
+		Namespace: src.ID.Namespace,
+		FieldPath: field,
+	}}
+
+	var targets []int
+	err = askOne(&survey.MultiSelect{Message: "Replacement targets:", Options: names}, &targets, survey.WithValidator(survey.Required))
+	if err != nil {
+		return Replacement{}, err
+	}
+	for _, t := range targets {
+		target, err := askReplacementTarget(objects[t])
+		if err != nil {
+			return Replacement{}, err
+		}
+		if len(target.FieldPaths) == 0 {
+			note("No fields selected for", names[t]+", skipping it.")
+			continue
+		}
+		r.Targets = append(r.Targets, target)
+	}
+	return r, nil
+}
+
+func askReplacementTarget(o resourceObject) (ReplacementTarget, error) {
+	t := ReplacementTarget{Select: &Selector{Group: o.ID.Group, Version: o.ID.Version, Kind: o.ID.Kind, Name: o.ID.Name}}
+	err := askOne(&survey.MultiSelect{
+		Message: "Fields of " + o.ID.Name + " to replace:",
+		Options: leafPaths(o.Fields, ""),
+	}, &t.FieldPaths)
+	if err != nil {
+		return t, err
+	}
+	other, err := askList("Other field of "+o.ID.Name,
+		"A field path such as spec.template.spec.containers.[name=app].env.[name=DB_HOST].value.", validateFieldOf(o))
+	if err != nil || len(other) == 0 && len(t.FieldPaths) == 0 {
+		return t, err
+	}
+	t.FieldPaths = append(t.FieldPaths, other...)
+
+	var delimiter string
+	err = askOne(&survey.Input{
+		Message: "Delimiter to replace part of the values (optional):",
+		Help:    "E.g. : replaces the host of db:5432 with index 0, keeping the port.",
+	}, &delimiter)
+	if err != nil || delimiter == "" {
+		return t, err
+	}
+	var index string
+	err = askOne(&survey.Input{Message: "Index of the part to replace:", Default: "0"}, &index,
+		survey.WithValidator(validateNonNegativeInt), survey.WithValidator(func(ans interface{}) error {
+			i, _ := strconv.Atoi(answer(ans))
+			for _, path := range t.FieldPaths {
+				v, _ := lookupField(o.Fields, path)
+				if parts := strings.Split(fmt.Sprint(v), delimiter); i >= len(parts) {
+					return fmt.Errorf("%s has %d parts split at %q", path, len(parts), delimiter)
+				}
+			}
+			return nil
+		}))
+	if err != nil {
+		return t, err
+	}
+	i, _ := strconv.Atoi(index)
+	t.Options = &FieldOptions{Delimiter: delimiter, Index: i}
+	return t, nil
+}
@@ -0,0 +1,42 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadResourceObjectsIncludesGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l := NewLayout("shop", []string{"dev"})
+	l.Base.AddResource("config.yaml")
+	l.Base.AddResource("deployment.yaml")
+	l.AddFiles(BaseDir, File{Path: "deployment.yaml", Content: []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: shop\n")})
+	l.AddFiles(BaseDir, File{Path: "app.env", Content: []byte("MODE=fast\n")})
+	l.Base.ConfigMapGenerator = []ConfigMapArgs{{Name: "app", Envs: []string{"app.env"}}}
+
+	objects, err := readResourceObjects(dir, l.Base, l.Generated(BaseDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, o := range objects {
+		got = append(got, o.ID.String())
+	}
+	want := []string{"v1 ConfigMap config", "apps/v1 Deployment shop", "v1 ConfigMap app"}
+	if len(got) != len(want) {
+		t.Fatalf("objects = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("objects = %v, want %v", got, want)
+			break
+		}
+	}
+	if _, ok := lookupField(objects[2].Fields, "data.MODE"); !ok {
+		t.Errorf("generated env file not read: %v", objects[2].Fields)
+	}
+}
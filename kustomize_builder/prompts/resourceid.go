@@ -0,0 +1,108 @@
+package prompts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceID identifies a Kubernetes object in a manifest.
+type ResourceID struct {
+	Group     string
+	Version   string
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+func (id ResourceID) String() string {
+	return apiVersion(id) + " " + id.Kind + " " + id.Name
+}
+
+type objectHeader struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// ReadResourceIDs returns the objects declared in the given resource files,
+// which are relative to dir. Files among generated, also relative to dir,
+// are read from there rather than from disk. Remote resources and
+// directories are skipped.
+func ReadResourceIDs(dir string, resources []string, generated []File) ([]ResourceID, error) {
+	var ids []ResourceID
+	for _, r := range resources {
+		content, ok, err := readResource(dir, r, generated)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		found, err := readManifestIDs(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r, err)
+		}
+		ids = append(ids, found...)
+	}
+	return ids, nil
+}
+
+// readResource returns the content of the file r relative to dir, taking
+// it from generated when it is one of them and from disk otherwise. It
+// reports false for remote resources, directories and missing files.
+func readResource(dir, r string, generated []File) ([]byte, bool, error) {
+	if isRemote(r) {
+		return nil, false, nil
+	}
+	for _, f := range generated {
+		if f.Path == path.Clean(r) {
+			return f.Content, true, nil
+		}
+	}
+	p := filepath.Join(dir, filepath.FromSlash(r))
+	if info, err := os.Stat(p); err != nil || info.IsDir() {
+		return nil, false, nil
+	}
+	content, err := os.ReadFile(p)
+	return content, err == nil, err
+}
+
+func readManifestIDs(content []byte) ([]ResourceID, error) {
+	var ids []ResourceID
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var h objectHeader
+		err := dec.Decode(&h)
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Kind == "" {
+			continue
+		}
+		ids = append(ids, idFromHeader(h))
+	}
+}
+
+func idFromHeader(h objectHeader) ResourceID {
+	id := ResourceID{Kind: h.Kind, Name: h.Metadata.Name, Namespace: h.Metadata.Namespace}
+	if group, version, ok := strings.Cut(h.APIVersion, "/"); ok {
+		id.Group, id.Version = group, version
+	} else {
+		id.Version = h.APIVersion
+	}
+	return id
+}
@@ -0,0 +1,107 @@
+package prompts
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// DiscoverManifests returns the YAML files below dir, skipping kustomization
+// files and hidden directories.
+func DiscoverManifests(dir string) ([]string, error) {
+	var manifests []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch d.Name() {
+		case "kustomization.yaml", "kustomization.yml", "Kustomization":
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml":
+			manifests = append(manifests, path)
+		}
+		return nil
+	})
+	return manifests, err
+}
+
+// ResourceOptions asks for a directory of existing manifests and adds the
+// selected ones to k's resources, relative to outDir.
+func ResourceOptions(k *Kustomization, outDir string) error {
+	var dir string
+	err := askOne(&survey.Input{
+		Message: "Directory with existing manifests (optional):",
+		Help:    "YAML files found below this directory can be included as resources of the kustomization.",
+	}, &dir)
+	if err != nil || dir == "" {
+		return err
+	}
+
+	manifests, err := DiscoverManifests(dir)
+	if err != nil {
+		return err
+	}
+	if len(manifests) == 0 {
+		return nil
+	}
+
+	rels := make([]string, len(manifests))
+	var included []string
+	for i, path := range manifests {
+		if rels[i], err = relativeTo(outDir, path); err != nil {
+			return err
+		}
+		if containsString(k.Resources, rels[i]) {
+			included = append(included, path)
+		}
+	}
+	if len(included) == 0 {
+		included = manifests
+	}
+
+	var selected []int
+	err = askOne(&survey.MultiSelect{
+		Message: "Select resources to include:",
+		Options: manifests,
+		Default: included,
+	}, &selected)
+	if err != nil {
+		return err
+	}
+
+	for _, rel := range rels {
+		k.RemoveResource(rel)
+	}
+	for _, i := range selected {
+		k.AddResource(rels[i])
+	}
+	return nil
+}
+
+// relativeTo returns path relative to base, using forward slashes as
+// kustomize expects.
+func relativeTo(base, path string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,262 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Routing backends.
+const (
+	BackendIstio      = "Istio"
+	BackendGatewayAPI = "Gateway API"
+	BackendIngress    = "Ingress (NGINX)"
+)
+
+// Routing is how traffic reaches the app. Public exposure goes through
+// internet-facing gateways or ingress controllers, Private through internal
+// ones, whichever the backend.
+type Routing struct {
+	Backend string
+	Public  bool
+	Private bool
+	TLS     bool
+	// TLSSecret is the secret holding the certificate, once known.
+	TLSSecret string
+	// Hosts are the base hostnames by exposure, and EnvHosts the overlays'
+	// own, by environment and exposure.
+	Hosts    map[string][]string
+	EnvHosts map[string]map[string][]string
+	// Subsets split the app's traffic by weight, when canarying.
+	Subsets []Subset
+
+	routes []route
+}
+
+// route is a generated routing resource, kept to regenerate it for other
+// hosts.
+type route struct {
+	File     string
+	Exposure string
+	Manifest func(hosts []string) map[string]interface{}
+}
+
+// Exposures returns the selected exposures, "Public" before "Private".
+func (r Routing) Exposures() []string {
+	var exposures []string
+	if r.Public {
+		exposures = append(exposures, "Public")
+	}
+	if r.Private {
+		exposures = append(exposures, "Private")
+	}
+	return exposures
+}
+
+// HostsFor returns the hostnames of an exposure in the overlay of env, or in
+// the base when env is empty. An empty exposure returns those of all
+// exposures.
+func (r Routing) HostsFor(app, env, exposure string) []string {
+	if exposure == "" {
+		var hosts []string
+		for _, e := range r.Exposures() {
+			hosts = append(hosts, r.HostsFor(app, env, e)...)
+		}
+		return hosts
+	}
+	if hosts := r.EnvHosts[env][exposure]; len(hosts) > 0 {
+		return hosts
+	}
+	if hosts := r.Hosts[exposure]; len(hosts) > 0 {
+		return hosts
+	}
+	return []string{defaultHost(app, exposure)}
+}
+
+// routingModule asks for the routing backend and exposure, which the
+// backend modules then generate resources for.
+type routingModule struct{}
+
+func (routingModule) Name() string { return "Routing" }
+
+func (routingModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "backend",
+			Prompt: &survey.Select{
+				Message: "Routing backend:",
+				Options: []string{BackendIstio, BackendGatewayAPI, BackendIngress},
+			},
+		},
+		{
+			Name: "exposure",
+			Prompt: &survey.MultiSelect{
+				Message: "Expose the app publicly, privately or both:",
+				Options: []string{"Public", "Private"},
+				Help:    "Public routes through the internet-facing gateway, Private through the internal one.",
+			},
+		},
+		{
+			Name: "publicHosts",
+			Prompt: &survey.Input{
+				Message: "Public hostnames, comma separated (optional):",
+				Help:    "Wildcards such as *.example.com are allowed. Defaults to <app>.example.com.",
+			},
+			Validate: validateHostnames,
+		},
+		{
+			Name: "privateHosts",
+			Prompt: &survey.Input{
+				Message: "Private hostnames, comma separated (optional):",
+				Help:    "Defaults to <app>.internal.example.com.",
+			},
+			Validate: validateHostnames,
+		},
+		{
+			Name:   "tls",
+			Prompt: &survey.Confirm{Message: "Terminate TLS with a cert-manager certificate?"},
+		},
+	}
+}
+
+// Asks asks for the hostnames of the selected exposures only, and about
+// TLS when the app is exposed at all.
+func (routingModule) Asks(name string, answers Answers) bool {
+	exposures := answers.Strings("exposure")
+	switch name {
+	case "publicHosts":
+		return containsString(exposures, "Public")
+	case "privateHosts":
+		return containsString(exposures, "Private")
+	case "tls":
+		return len(exposures) > 0
+	}
+	return true
+}
+
+func (routingModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	r := Routing{
+		Backend: answers.String("backend"),
+		TLS:     answers.Bool("tls"),
+		Hosts: map[string][]string{
+			"Public":  splitList(answers.String("publicHosts")),
+			"Private": splitList(answers.String("privateHosts")),
+		},
+		EnvHosts: map[string]map[string][]string{},
+	}
+	for _, exposure := range answers.Strings("exposure") {
+		switch exposure {
+		case "Public":
+			r.Public = true
+		case "Private":
+			r.Private = true
+		}
+	}
+
+	if len(l.Envs) > 1 {
+		for _, env := range l.Envs {
+			r.EnvHosts[env] = map[string][]string{}
+			for _, exposure := range r.Exposures() {
+				var hosts string
+				err := askOne(&survey.Input{
+					Message: exposure + " hostnames for " + env + " (optional):",
+					Help:    "Overrides the base hostnames " + strings.Join(r.HostsFor(l.App, "", exposure), ", ") + " in this overlay.",
+				}, &hosts, survey.WithValidator(validateHostnames))
+				if err != nil {
+					return nil, err
+				}
+				r.EnvHosts[env][exposure] = splitList(hosts)
+			}
+		}
+	}
+	for _, warning := range r.overlaps(l.App, l.Envs) {
+		note("Warning:", warning)
+	}
+	l.Routing = r
+	return nil, nil
+}
+
+// overlaps describes the hosts routed by more than one overlay. Overlays
+// that all keep the base hosts are assumed to run on separate clusters.
+func (r Routing) overlaps(app string, envs []string) []string {
+	var warnings []string
+	for _, exposure := range r.Exposures() {
+		for i, a := range envs {
+			for _, b := range envs[i+1:] {
+				if len(r.EnvHosts[a][exposure]) == 0 && len(r.EnvHosts[b][exposure]) == 0 {
+					continue
+				}
+				for _, ha := range r.HostsFor(app, a, exposure) {
+					for _, hb := range r.HostsFor(app, b, exposure) {
+						if hostsOverlap(ha, hb) {
+							warnings = append(warnings, fmt.Sprintf("%s hosts %s of %s and %s of %s overlap", exposure, ha, a, hb, b))
+						}
+					}
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// hostsOverlap reports whether two hostnames can match the same host. A
+// wildcard matches a single label.
+func hostsOverlap(a, b string) bool {
+	return a == b || matchesWildcard(a, b) || matchesWildcard(b, a)
+}
+
+func matchesWildcard(pattern, host string) bool {
+	if !strings.HasPrefix(pattern, "*") {
+		return false
+	}
+	suffix := pattern[1:]
+	if !strings.HasSuffix(host, suffix) {
+		return false
+	}
+	label := strings.TrimSuffix(host, suffix)
+	return label != "" && label != "*" && !strings.Contains(label, ".")
+}
+
+// routeFiles returns a routing resource generated into the base from
+// manifest, with a patch for every overlay whose hosts of the exposure change
+// it.
+func routeFiles(l *Layout, file, exposure string, manifest func(hosts []string) map[string]interface{}) ([]File, error) {
+	base, err := marshalYAML(manifest(l.Routing.HostsFor(l.App, "", exposure)))
+	if err != nil {
+		return nil, err
+	}
+	files := []File{{Path: path.Join(BaseDir, file), Content: base}}
+	l.Base.AddResource(file)
+	l.Routing.routes = append(l.Routing.routes, route{File: file, Exposure: exposure, Manifest: manifest})
+
+	for _, env := range l.Envs {
+		content, err := marshalYAML(manifest(l.Routing.HostsFor(l.App, env, exposure)))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(content, base) {
+			continue
+		}
+		patch := "patches/" + file
+		files = append(files, File{Path: path.Join(OverlayDir(env), patch), Content: content})
+		l.Overlays[env].AddPatch(Patch{Path: patch})
+	}
+	return files, nil
+}
+
+// usesBackend reports whether the layout routes traffic through backend.
+func usesBackend(l *Layout, backend string) bool {
+	return l.Routing.Backend == backend && len(l.Routing.Exposures()) > 0
+}
+
+// defaultHost returns the placeholder host of the app for an exposure.
+func defaultHost(app, exposure string) string {
+	if exposure == "Private" {
+		return app + ".internal.example.com"
+	}
+	return app + ".example.com"
+}
@@ -0,0 +1,41 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoutingAsksForTheSelectedExposures(t *testing.T) {
+	for _, tc := range []struct {
+		exposure string
+		asked    []string
+		skipped  []string
+	}{
+		{"Public", []string{"Public hostnames", "Terminate TLS"}, []string{"Private hostnames"}},
+		{"Private", []string{"Private hostnames", "Terminate TLS"}, []string{"Public hostnames"}},
+		{"-", nil, []string{"Public hostnames", "Private hostnames", "Terminate TLS"}},
+	} {
+		s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev"})}
+		sc := &Script{
+			Answers: map[string]string{
+				"Routing backend:": BackendIngress,
+				"Expose the app publicly, privately or both:": tc.exposure,
+			},
+			Defaults: true,
+		}
+		if _, err := sc.Wizard(s, []Step{ModuleStep(routingModule{})}); err != nil {
+			t.Fatalf("%s: %v", tc.exposure, err)
+		}
+		transcript := sc.Transcript.String()
+		for _, q := range tc.asked {
+			if !strings.Contains(transcript, q) {
+				t.Errorf("%s: %q not asked\n%s", tc.exposure, q, transcript)
+			}
+		}
+		for _, q := range tc.skipped {
+			if strings.Contains(transcript, q) {
+				t.Errorf("%s: %q asked\n%s", tc.exposure, q, transcript)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const rootReadme = `# %[1]s
+
+Kustomize configuration of %[1]s, generated with kustomize_builder.
+
+- base/ holds the manifests shared by all environments.
+- overlays/ holds one overlay per environment: %[2]s.
+- components/ holds optional features overlays can include.
+
+Run kustomize_builder edit . to add to it, and build an environment with
+kustomize build overlays/<env>.
+`
+
+const baseReadme = `# base
+
+Manifests shared by all environments of %s. List each file under
+resources in kustomization.yaml. Settings that differ per environment
+belong in the overlays.
+`
+
+const overlaysReadme = `# overlays
+
+One directory per environment, each building on ../../base. Patches,
+images and replica counts that only apply to one environment go here.
+`
+
+const componentsReadme = `# components
+
+Kustomize components: optional features, such as monitoring or a
+PodDisruptionBudget, that overlays opt into by listing them under
+components, e.g. ../../components/<name>.
+`
+
+// Scaffold returns the canonical directory skeleton of app: minimal
+// kustomizations for the base and an overlay per environment, and a README
+// in each directory saying what belongs there.
+func Scaffold(app string, envs []string) ([]File, error) {
+	l := NewLayout(app, envs)
+	l.Files = []File{
+		{Path: "README.md", Content: []byte(fmt.Sprintf(rootReadme, app, strings.Join(envs, ", ")))},
+		{Path: path.Join(BaseDir, "README.md"), Content: []byte(fmt.Sprintf(baseReadme, app))},
+		{Path: path.Join("overlays", "README.md"), Content: []byte(overlaysReadme)},
+		{Path: path.Join("components", "README.md"), Content: []byte(componentsReadme)},
+	}
+	return l.Render()
+}
@@ -0,0 +1,168 @@
+package prompts
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// script, when set, answers the prompts instead of the user.
+var script *Script
+
+// Script answers prompts from a script rather than the terminal, so prompt
+// modules and whole wizard runs can be tested. Answers are written as in
+// Plain mode: y or n, option numbers or text, comma separated options, - for
+// no options and an empty line for the default.
+type Script struct {
+	// Answers answers prompts by their message, every time they are asked.
+	// Prompts it has no answer for take the next line of the script.
+	Answers map[string]string
+	// Interactive is what Interactive reports while the script runs. The
+	// wizard only asks how to continue, and for the summary, when it is set.
+	Interactive bool
+	// Defaults answers the prompts left without an answer with their
+	// default, as an empty line does, rather than failing.
+	Defaults bool
+	// Transcript records the questions asked with their answers, and the
+	// notes and step titles printed in between. Password answers are masked.
+	Transcript bytes.Buffer
+
+	lines *bufio.Reader
+}
+
+// NewScript returns a script answering prompts with the lines of r, in the
+// order they are asked.
+func NewScript(r io.Reader) *Script {
+	return &Script{lines: bufio.NewReader(r)}
+}
+
+// Run runs f with the prompts answered by sc. Prompts without an answer fail
+// rather than wait for input, as do answers the prompt rejects.
+func (sc *Script) Run(f func() error) error {
+	prev := script
+	script = sc
+	defer func() { script = prev }()
+	return f()
+}
+
+// Wizard runs the steps on s with sc answering, and returns the files the
+// resulting layout renders to.
+func (sc *Script) Wizard(s *State, steps []Step) ([]File, error) {
+	if s.Skip == nil {
+		s.Skip = map[string]bool{}
+	}
+	err := sc.Run(func() error {
+		return (&Wizard{Steps: steps}).Run(s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Layout.Render()
+}
+
+// answer returns the scripted answer to the prompt with message.
+func (sc *Script) answer(message string) (string, error) {
+	if a, ok := sc.Answers[message]; ok {
+		return a, nil
+	}
+	if sc.lines == nil {
+		return sc.unanswered(message)
+	}
+	line, err := sc.lines.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return sc.unanswered(message)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (sc *Script) unanswered(message string) (string, error) {
+	if sc.Defaults {
+		return "", nil
+	}
+	return "", fmt.Errorf("no scripted answer for %q", message)
+}
+
+// askScript answers p from the running script.
+func askScript(p survey.Prompt, validators []survey.Validator, write func(interface{}) error) error {
+	message, _, _ := plainPrompt(p)
+	line, err := script.answer(message)
+	if err != nil {
+		return err
+	}
+	v, err := plainAnswer(p, line)
+	for _, validate := range validators {
+		if err == nil {
+			err = validate(v)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s %q: %w", message, line, err)
+	}
+	shown := line
+	if _, ok := p.(*survey.Password); ok && line != "" {
+		shown = "********"
+	}
+	fmt.Fprintf(&script.Transcript, "%s %s\n", message, shown)
+	return write(v)
+}
+
+// Golden compares files with the golden files in dir, reporting the paths
+// that differ, are missing or are not generated. With update set, dir is
+// rewritten to hold files instead.
+func Golden(dir string, files []File, update bool) error {
+	if update {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		return WriteFiles(dir, files)
+	}
+
+	golden := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		golden[filepath.ToSlash(rel)] = content
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, f := range files {
+		content, ok := golden[f.Path]
+		delete(golden, f.Path)
+		switch {
+		case !ok:
+			problems = append(problems, f.Path+": not in golden files")
+		case !bytes.Equal(content, f.Content):
+			problems = append(problems, fmt.Sprintf("%s: differs\n--- golden\n%s+++ generated\n%s", f.Path, content, f.Content))
+		}
+	}
+	for p := range golden {
+		problems = append(problems, p+": not generated")
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("generated files do not match %s:\n%s", dir, strings.Join(problems, "\n"))
+	}
+	return nil
+}
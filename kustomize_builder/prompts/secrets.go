@@ -0,0 +1,199 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// SecretArgs is a secretGenerator entry.
+type SecretArgs struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type,omitempty"`
+	Literals []string `yaml:"literals,omitempty"`
+	Envs     []string `yaml:"envs,omitempty"`
+	Files    []string `yaml:"files,omitempty"`
+}
+
+const (
+	secretGenerator = "secretGenerator (values stay in local files)"
+	secretKubeseal  = "Sealed Secret (kubeseal)"
+	secretSOPS      = "SOPS encrypted Secret"
+)
+
+type secretManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   map[string]string `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	StringData map[string]string `yaml:"stringData"`
+}
+
+// SecretOptions asks for secrets and either adds secretGenerator entries to k
+// or returns sealed/encrypted Secret manifests, so secret values are never
+// written in plaintext.
+func SecretOptions(k *Kustomization) ([]File, error) {
+	var files []File
+	var err error
+	k.SecretGenerator, err = keepEntries("Keep secretGenerator entries:", k.SecretGenerator,
+		func(a SecretArgs) string { return a.Name })
+	if err != nil {
+		return nil, err
+	}
+
+	more, err := askMore("Add a secret?")
+	for ; err == nil && more; more, err = askMore("Add another secret?") {
+		var name, kind, mode string
+		err = askOne(&survey.Input{Message: "Secret name:"}, &name,
+			survey.WithValidator(survey.Required), survey.WithValidator(ValidateDNSSubdomain))
+		if err != nil {
+			return nil, err
+		}
+		err = askOne(&survey.Select{
+			Message: "Secret type:",
+			Options: []string{"Opaque", "kubernetes.io/tls", "kubernetes.io/dockerconfigjson", "kubernetes.io/basic-auth"},
+			Default: "Opaque",
+		}, &kind)
+		if err != nil {
+			return nil, err
+		}
+		err = askOne(&survey.Select{
+			Message: "How should " + name + " be stored?",
+			Options: []string{secretGenerator, secretKubeseal, secretSOPS},
+		}, &mode)
+		if err != nil {
+			return nil, err
+		}
+
+		if mode == secretGenerator {
+			args := SecretArgs{Name: name, Type: kind}
+			if args.Envs, err = askList("Env file", "Path to a file of KEY=VALUE lines. Keep it out of version control.", nil); err != nil {
+				return nil, err
+			}
+			if args.Files, err = askList("File source [KEY=]PATH", "The key defaults to the file name.", validateFileSource); err != nil {
+				return nil, err
+			}
+			k.SecretGenerator = append(k.SecretGenerator, args)
+			continue
+		}
+
+		f, err := encryptedSecret(k.Namespace, name, kind, mode)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		k.AddResource(f.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(k.SecretGenerator) > 0 {
+		err = generatorOptions(k)
+	}
+	return files, err
+}
+
+func encryptedSecret(namespace, name, kind, mode string) (File, error) {
+	data, err := secretValues()
+	if err != nil {
+		return File{}, err
+	}
+	metadata := map[string]string{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	plain, err := marshalYAML(secretManifest{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   metadata,
+		Type:       kind,
+		StringData: data,
+	})
+	if err != nil {
+		return File{}, err
+	}
+
+	if mode == secretKubeseal {
+		var args []string
+		var cert string
+		err := askOne(&survey.Input{
+			Message: "Sealing certificate (optional):",
+			Help:    "Path or URL of the controller's public certificate. When empty, kubeseal fetches it from the current cluster.",
+		}, &cert)
+		if err != nil {
+			return File{}, err
+		}
+		args = append(args, "--format", "yaml")
+		if cert != "" {
+			args = append(args, "--cert", cert)
+		}
+		out, err := pipe(plain, "kubeseal", args...)
+		if err != nil {
+			return File{}, err
+		}
+		return File{Path: name + "-sealedsecret.yaml", Content: out}, nil
+	}
+
+	var recipient string
+	err = askOne(&survey.Input{
+		Message: "age recipient (optional):",
+		Help:    "When empty, SOPS uses the creation rules from .sops.yaml.",
+	}, &recipient)
+	if err != nil {
+		return File{}, err
+	}
+	args := []string{"--encrypt", "--input-type", "yaml", "--output-type", "yaml",
+		"--encrypted-regex", "^(data|stringData)$"}
+	if recipient != "" {
+		args = append(args, "--age", recipient)
+	}
+	args = append(args, "/dev/stdin")
+	out, err := pipe(plain, "sops", args...)
+	if err != nil {
+		return File{}, err
+	}
+	return File{Path: name + "-secret.enc.yaml", Content: out}, nil
+}
+
+// secretValues asks for secret keys and their values without echoing them.
+func secretValues() (map[string]string, error) {
+	data := map[string]string{}
+	for {
+		var key string
+		err := askOne(&survey.Input{Message: "Secret key (empty to finish)"}, &key,
+			survey.WithValidator(Optional(func(ans interface{}) error {
+				return validateConfigMapKey(answer(ans))
+			})))
+		if err != nil {
+			return nil, err
+		}
+		if key == "" {
+			break
+		}
+		var value string
+		if err := askOne(&survey.Password{Message: "Value for " + key + ":"}, &value); err != nil {
+			return nil, err
+		}
+		data[key] = value
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret has no keys")
+	}
+	return data, nil
+}
+
+// pipe runs name with input on stdin and returns its stdout.
+func pipe(input []byte, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+package prompts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Sizing is the replica count and container resources of a workload in one
+// environment. Empty quantities are left unset, as are the replicas of jobs,
+// which have none.
+type Sizing struct {
+	Replicas      int
+	CPURequest    string
+	CPULimit      string
+	MemoryRequest string
+	MemoryLimit   string
+}
+
+// check reports limits that are lower than their requests.
+func (s Sizing) check() error {
+	for _, pair := range [][3]string{
+		{"cpu", s.CPURequest, s.CPULimit},
+		{"memory", s.MemoryRequest, s.MemoryLimit},
+	} {
+		if pair[1] == "" || pair[2] == "" {
+			continue
+		}
+		request, limit := resource.MustParse(pair[1]), resource.MustParse(pair[2])
+		if limit.Cmp(request) < 0 {
+			return fmt.Errorf("%s limit %s is lower than the request %s", pair[0], pair[2], pair[1])
+		}
+	}
+	return nil
+}
+
+func (s Sizing) resources() map[string]interface{} {
+	requests, limits := map[string]interface{}{}, map[string]interface{}{}
+	if s.CPURequest != "" {
+		requests["cpu"] = s.CPURequest
+	}
+	if s.MemoryRequest != "" {
+		requests["memory"] = s.MemoryRequest
+	}
+	if s.CPULimit != "" {
+		limits["cpu"] = s.CPULimit
+	}
+	if s.MemoryLimit != "" {
+		limits["memory"] = s.MemoryLimit
+	}
+	res := map[string]interface{}{}
+	if len(requests) > 0 {
+		res["requests"] = requests
+	}
+	if len(limits) > 0 {
+		res["limits"] = limits
+	}
+	return res
+}
+
+// defaultReplicas suggests more replicas for production-like environments.
+func defaultReplicas(env string) string {
+	if strings.HasPrefix(env, "prod") {
+		return "3"
+	}
+	return "1"
+}
+
+// SizingOptions asks for replicas and resources of a workload per
+// environment and adds the resulting patch to each overlay. Workloads of the
+// base resources, read from baseDir or generated, are offered as targets.
+func SizingOptions(l *Layout, baseDir string) error {
+	var set bool
+	if err := askOne(&survey.Confirm{Message: "Set replicas and resources per environment?"}, &set); err != nil || !set {
+		return err
+	}
+
+	target, err := askWorkload(l, baseDir)
+	if err != nil {
+		return err
+	}
+	var container string
+	err = askOne(&survey.Input{Message: "Container name:", Default: target.Name}, &container,
+		survey.WithValidator(survey.Required), survey.WithValidator(ValidateDNSLabel))
+	if err != nil {
+		return err
+	}
+
+	scaled := runsReplicas(target.Kind)
+	for _, env := range l.Envs {
+		sizing, err := askSizing(env, scaled)
+		if err != nil {
+			return err
+		}
+		template := map[string]interface{}{
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": container, "resources": sizing.resources()},
+				},
+			},
+		}
+		spec := map[string]interface{}{"template": template}
+		switch target.Kind {
+		case KindCronJob:
+			spec = map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": spec}}
+		case KindDeployment, KindStatefulSet:
+			spec["replicas"] = sizing.Replicas
+		}
+		content, err := marshalYAML(map[string]interface{}{
+			"apiVersion": apiVersion(target),
+			"kind":       target.Kind,
+			"metadata":   map[string]interface{}{"name": target.Name},
+			"spec":       spec,
+		})
+		if err != nil {
+			return err
+		}
+		path := "patches/sizing-" + target.Name + ".yaml"
+		l.AddFiles(OverlayDir(env), File{Path: path, Content: content})
+		l.Overlays[env].AddPatch(Patch{Path: path})
+	}
+	return nil
+}
+
+// askSizing asks for the sizing of a workload in env, with the replicas
+// when the workload is scaled by them.
+func askSizing(env string, scaled bool) (Sizing, error) {
+	for {
+		answers := struct {
+			Replicas      string
+			CPURequest    string
+			CPULimit      string
+			MemoryRequest string
+			MemoryLimit   string
+		}{}
+		qs := []*survey.Question{
+			{Name: "Replicas", Prompt: &survey.Input{Message: env + " replicas:", Default: defaultReplicas(env)}, Validate: ValidatePositiveInt},
+			{Name: "CPURequest", Prompt: &survey.Input{Message: env + " CPU request:", Default: "100m"}, Validate: ValidateQuantity},
+			{Name: "CPULimit", Prompt: &survey.Input{Message: env + " CPU limit (optional):"}, Validate: Optional(ValidateQuantity)},
+			{Name: "MemoryRequest", Prompt: &survey.Input{Message: env + " memory request:", Default: "128Mi"}, Validate: ValidateQuantity},
+			{Name: "MemoryLimit", Prompt: &survey.Input{Message: env + " memory limit (optional):"}, Validate: Optional(ValidateQuantity)},
+		}
+		if !scaled {
+			qs = qs[1:]
+		}
+		if err := ask(qs, &answers); err != nil {
+			return Sizing{}, err
+		}
+		replicas, _ := strconv.Atoi(answers.Replicas)
+		s := Sizing{
+			Replicas:      replicas,
+			CPURequest:    answers.CPURequest,
+			CPULimit:      answers.CPULimit,
+			MemoryRequest: answers.MemoryRequest,
+			MemoryLimit:   answers.MemoryLimit,
+		}
+		if err := s.check(); err != nil {
+			note("Error:", err)
+			continue
+		}
+		return s, nil
+	}
+}
+
+// askWorkload asks which workload of the base to patch: a Deployment,
+// StatefulSet, CronJob or Job.
+func askWorkload(l *Layout, baseDir string) (ResourceID, error) {
+	ids, err := ReadResourceIDs(baseDir, l.Base.Resources, l.Generated(BaseDir))
+	if err != nil {
+		return ResourceID{}, err
+	}
+	var workloads []ResourceID
+	var options []string
+	for _, id := range ids {
+		switch id.Kind {
+		case KindDeployment, KindStatefulSet, KindCronJob, KindJob:
+			workloads = append(workloads, id)
+			options = append(options, id.String())
+		}
+	}
+	switch len(workloads) {
+	case 0:
+		id := ResourceID{Group: "apps", Version: "v1", Kind: "Deployment"}
+		err := askOne(&survey.Input{Message: "Deployment name:", Default: l.App}, &id.Name,
+			survey.WithValidator(survey.Required), survey.WithValidator(ValidateDNSSubdomain))
+		return id, err
+	case 1:
+		return workloads[0], nil
+	}
+	var i int
+	err = askOne(&survey.Select{Message: "Workload to size:", Options: options}, &i)
+	return workloads[i], err
+}
+
+func apiVersion(id ResourceID) string {
+	if id.Group == "" {
+		return id.Version
+	}
+	return id.Group + "/" + id.Version
+}
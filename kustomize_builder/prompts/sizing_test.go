@@ -0,0 +1,45 @@
+package prompts
+
+import (
+	"testing"
+)
+
+func TestSizingOptionsPatchesGeneratedCronJob(t *testing.T) {
+	l := NewLayout("shop", []string{"prod"})
+	l.Base.AddResource("cronjob.yaml")
+	l.AddFiles(BaseDir, File{Path: "cronjob.yaml", Content: []byte("apiVersion: batch/v1\nkind: CronJob\nmetadata:\n  name: shop\n")})
+
+	sc := &Script{
+		Answers: map[string]string{
+			"Set replicas and resources per environment?": "y",
+			"prod CPU limit (optional):":                  "500m",
+		},
+		Defaults: true,
+	}
+	if err := sc.Run(func() error { return SizingOptions(l, t.TempDir()) }); err != nil {
+		t.Fatalf("%v\n%s", err, sc.Transcript.String())
+	}
+
+	want := `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: shop
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: shop
+              resources:
+                limits:
+                  cpu: 500m
+                requests:
+                  cpu: 100m
+                  memory: 128Mi
+`
+	files := l.Generated(OverlayDir("prod"))
+	if len(files) != 1 || string(files[0].Content) != want {
+		t.Errorf("overlay files = %+v, want patch:\n%s", files, want)
+	}
+}
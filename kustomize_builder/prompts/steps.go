@@ -0,0 +1,94 @@
+package prompts
+
+import (
+	"path/filepath"
+)
+
+// DefaultSteps are the wizard steps of a full run. Registered modules run
+// after the base has been set up and before it is patched.
+func DefaultSteps() []Step {
+	steps := []Step{
+		{Title: "Application", Run: func(s *State) error {
+			defaultApp := s.Layout.App
+			if abs, err := filepath.Abs(s.OutDir); err == nil && defaultApp == "" {
+				defaultApp = filepath.Base(abs)
+			}
+			app, err := AppOptions(defaultApp)
+			s.Layout.App = app
+			return err
+		}},
+		{Title: "Preset", Run: PresetOptions},
+		{Title: "Environments", Run: func(s *State) error {
+			envs, err := EnvironmentOptions(s.Layout.Envs)
+			s.Layout.SetEnvs(envs)
+			return err
+		}},
+		{Title: "Namespace", Run: func(s *State) error {
+			files, err := NamespaceOptions(s.Layout.Base)
+			s.Layout.AddFiles(BaseDir, files...)
+			return err
+		}},
+		{Title: "Labels", Run: func(s *State) error {
+			return LabelOptions(s.Layout, s.OutDir)
+		}},
+		{Title: "Resources", Run: func(s *State) error {
+			return ResourceOptions(s.Layout.Base, s.BaseDir())
+		}},
+		{Title: "Remote bases", Run: func(s *State) error {
+			return RemoteBaseOptions(s.Layout.Base)
+		}},
+		{Title: "ConfigMaps", Run: func(s *State) error {
+			return ConfigMapOptions(s.Layout.Base)
+		}},
+		{Title: "Secrets", Run: func(s *State) error {
+			files, err := SecretOptions(s.Layout.Base)
+			s.Layout.AddFiles(BaseDir, files...)
+			return err
+		}},
+		{Title: "Helm charts", Run: func(s *State) error {
+			files, err := HelmOptions(s.Layout.Base)
+			s.Layout.AddFiles(BaseDir, files...)
+			return err
+		}},
+	}
+	for _, m := range Modules() {
+		steps = append(steps, ModuleStep(m))
+	}
+	return append(steps, []Step{
+		{
+			Title: "Patches",
+			When: func(s *State) bool {
+				return len(s.Layout.Base.Resources) > 0 || len(s.Layout.Base.HelmCharts) > 0
+			},
+			Run: func(s *State) error {
+				files, err := PatchOptions(s.Layout, s.BaseDir())
+				s.Layout.AddFiles(BaseDir, files...)
+				return err
+			},
+		},
+		{
+			Title: "Replacements",
+			When: func(s *State) bool {
+				return len(s.Layout.Base.Resources) > 0 || len(s.Layout.Base.ConfigMapGenerator) > 0
+			},
+			Run: func(s *State) error {
+				return ReplacementOptions(s.Layout, s.BaseDir())
+			},
+		},
+		{Title: "Components", Run: func(s *State) error {
+			return ComponentOptions(s.Layout, s.OutDir)
+		}},
+		{Title: "Images", Run: func(s *State) error {
+			return ImageOptions(s.Layout, s.BaseDir())
+		}},
+		{Title: "Sizing", Run: func(s *State) error {
+			return SizingOptions(s.Layout, s.BaseDir())
+		}},
+		{Title: "Clusters", Run: func(s *State) error {
+			return ClusterOptions(s.Layout)
+		}},
+		{Title: "GitOps", Run: func(s *State) error {
+			return GitOpsOptions(s.Layout, s.OutDir)
+		}},
+	}...)
+}
@@ -0,0 +1,77 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Summary describes the answers given so far and the files they produce.
+func Summary(l *Layout) (string, error) {
+	var b strings.Builder
+	line := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-14s %s\n", label+":", value)
+		}
+	}
+	line("Application", l.App)
+	line("Environments", strings.Join(l.Envs, ", "))
+	line("Namespace", l.Base.Namespace)
+	line("Resources", strings.Join(l.Base.Resources, ", "))
+	if n := len(l.Base.Replacements); n > 0 {
+		line("Replacements", strconv.Itoa(n))
+	}
+	if exposures := l.Routing.Exposures(); len(exposures) > 0 {
+		routing := l.Routing.Backend + ", " + strings.Join(exposures, " and ")
+		for _, exposure := range exposures {
+			routing += "; " + exposure + " " + strings.Join(l.Routing.HostsFor(l.App, "", exposure), ", ")
+		}
+		line("Routing", routing)
+		line("TLS secret", l.Routing.TLSSecret)
+	}
+	for _, env := range l.Envs {
+		o := l.Overlays[env]
+		var parts []string
+		if len(o.Components) > 0 {
+			var names []string
+			for _, c := range o.Components {
+				names = append(names, path.Base(c))
+			}
+			parts = append(parts, "components "+strings.Join(names, ", "))
+		}
+		if len(o.Images) > 0 {
+			var images []string
+			for _, img := range o.Images {
+				images = append(images, img.Name+":"+img.NewTag+img.Digest)
+			}
+			parts = append(parts, "images "+strings.Join(images, ", "))
+		}
+		if len(o.Patches) > 0 {
+			parts = append(parts, fmt.Sprintf("%d patches", len(o.Patches)))
+		}
+		line("Overlay "+env, strings.Join(parts, "; "))
+	}
+	for _, c := range l.Clusters {
+		line("Cluster "+c.Name, strings.Join(c.Envs, ", "))
+	}
+
+	files, err := l.Render()
+	if err != nil {
+		return "", err
+	}
+	b.WriteString("\nFiles:\n")
+	var dirs []string
+	for _, f := range files {
+		parts := strings.Split(f.Path, "/")
+		for i := range parts[:len(parts)-1] {
+			if i >= len(dirs) || dirs[i] != parts[i] {
+				dirs = append(dirs[:i], parts[i])
+				fmt.Fprintf(&b, "%s%s/\n", strings.Repeat("  ", i+1), parts[i])
+			}
+		}
+		dirs = dirs[:len(parts)-1]
+		fmt.Fprintf(&b, "%s%s\n", strings.Repeat("  ", len(parts)), parts[len(parts)-1])
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,101 @@
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// transaction moves files into place, remembering what it replaced and
+// created so that it can be undone.
+type transaction struct {
+	// staging holds the staged files and the backups of replaced files. It
+	// is created next to the target directory, so that moving files in and
+	// out of it stays on one file system.
+	staging string
+
+	replaced map[string]string
+	created  []string
+	dirs     []string
+}
+
+// stage creates the staging directory next to dir, creating the parents of
+// dir as needed.
+func (t *transaction) stage(dir string) error {
+	parent := filepath.Dir(dir)
+	if err := t.mkdirAll(parent); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(dir)+"-")
+	t.staging = staging
+	return err
+}
+
+// replace moves src to dst, creating the parent directories of dst and
+// keeping a backup of dst when it exists.
+func (t *transaction) replace(src, dst string) error {
+	if err := t.mkdirAll(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(dst); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", dst)
+		}
+		backups := filepath.Join(t.staging, "backups")
+		if t.replaced == nil {
+			t.replaced = map[string]string{}
+			if err := os.MkdirAll(backups, 0o755); err != nil {
+				return err
+			}
+		}
+		backup := filepath.Join(backups, strconv.Itoa(len(t.replaced)))
+		if err := os.Rename(dst, backup); err != nil {
+			return err
+		}
+		t.replaced[dst] = backup
+	} else {
+		t.created = append(t.created, dst)
+	}
+	return os.Rename(src, dst)
+}
+
+// mkdirAll creates dir and its missing parents, recording them.
+func (t *transaction) mkdirAll(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+	if err := t.mkdirAll(filepath.Dir(dir)); err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	t.dirs = append(t.dirs, dir)
+	return nil
+}
+
+// rollback removes the created files and directories and restores the
+// replaced files, returning the first error. It removes the staging
+// directory before the directories it was created in.
+func (t *transaction) rollback() error {
+	var first error
+	keep := func(err error) {
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	for _, f := range t.created {
+		if err := os.Remove(f); !os.IsNotExist(err) {
+			keep(err)
+		}
+	}
+	for dst, backup := range t.replaced {
+		keep(os.Rename(backup, dst))
+	}
+	keep(os.RemoveAll(t.staging))
+	for i := len(t.dirs) - 1; i >= 0; i-- {
+		keep(os.Remove(t.dirs[i]))
+	}
+	return first
+}
@@ -0,0 +1,56 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFilesRollsBack(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "shop", "deploy")
+	if err := os.MkdirAll(filepath.Join(dir, "overlays", "dev", "kustomization.yaml"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := WriteFiles(dir, []File{
+		{Path: "README.md", Content: []byte("new\n")},
+		{Path: "base/kustomization.yaml", Content: []byte("kind: Kustomization\n")},
+		{Path: "overlays/dev/kustomization.yaml", Content: []byte("kind: Kustomization\n")},
+	})
+	if err == nil {
+		t.Fatal("writing over a directory succeeded")
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "README.md")); err != nil || string(content) != "old\n" {
+		t.Errorf("README.md = %q, %v after rollback, want the old content", content, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "base")); !os.IsNotExist(err) {
+		t.Errorf("created directory base left after rollback: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, "shop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "deploy" {
+		t.Errorf("staging directory left next to the output: %v", entries)
+	}
+}
+
+func TestWriteFilesRemovesCreatedParents(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "new", "deploy")
+	err := WriteFiles(dir, []File{
+		{Path: "base", Content: []byte("a file\n")},
+		{Path: "base/kustomization.yaml", Content: []byte("kind: Kustomization\n")},
+	})
+	if err == nil {
+		t.Fatal("writing below a file succeeded")
+	}
+	if _, err := os.Stat(filepath.Join(root, "new")); !os.IsNotExist(err) {
+		t.Errorf("created parent directory left after rollback: %v", err)
+	}
+}
@@ -0,0 +1,429 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/AlecAivazis/survey/v2/terminal"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TUI runs the wizard full screen, showing the steps, the answers so far and
+// a live preview of the generated kustomizations next to the current
+// question.
+var TUI bool
+
+// session is the running terminal UI, if any. The wizard runs in its own
+// goroutine and hands each question to the UI.
+var session *tuiSession
+
+type tuiSession struct {
+	program *tea.Program
+	// done is closed when the program has exited.
+	done chan struct{}
+	// state is the wizard state the preview is rendered from, and summary
+	// replaces the preview when set.
+	state   *State
+	summary string
+}
+
+type (
+	stepMsg struct {
+		titles  []string
+		current int
+	}
+	noteMsg     string
+	questionMsg struct {
+		prompt     survey.Prompt
+		validators []survey.Validator
+		preview    string
+		reply      chan interface{}
+	}
+	wizardDoneMsg struct{}
+)
+
+// runTUI runs wizard in the terminal UI, returning its error.
+func runTUI(wizard func() error) error {
+	m := &tuiModel{checked: map[int]bool{}}
+	t := &tuiSession{program: tea.NewProgram(m, tea.WithAltScreen(), tea.WithOutput(Out)), done: make(chan struct{})}
+	session = t
+	defer func() { session = nil }()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- wizard()
+		t.send(wizardDoneMsg{})
+	}()
+	_, err := t.program.Run()
+	close(t.done)
+	// The wizard uses the session until it returns, which it does once its
+	// next question finds the program exited.
+	wizardErr := <-result
+	if err != nil {
+		return err
+	}
+	return wizardErr
+}
+
+func (t *tuiSession) send(msg tea.Msg) {
+	select {
+	case <-t.done:
+	default:
+		t.program.Send(msg)
+	}
+}
+
+// preview renders the kustomizations of the current state.
+func (t *tuiSession) preview() string {
+	if t.summary != "" {
+		summary := t.summary
+		t.summary = ""
+		return summary
+	}
+	if t.state == nil {
+		return ""
+	}
+	files, err := t.state.Layout.Render()
+	if err != nil {
+		return "Error: " + err.Error()
+	}
+	var b strings.Builder
+	for _, f := range files {
+		if strings.HasSuffix(f.Path, "kustomization.yaml") {
+			fmt.Fprintf(&b, "# %s\n%s\n", f.Path, f.Content)
+		}
+	}
+	return b.String()
+}
+
+// askTUI hands p to the terminal UI and writes the answer it returns.
+func askTUI(p survey.Prompt, validators []survey.Validator, write func(interface{}) error) error {
+	reply := make(chan interface{}, 1)
+	session.send(questionMsg{prompt: p, validators: validators, preview: session.preview(), reply: reply})
+	select {
+	case v, ok := <-reply:
+		if !ok {
+			return terminal.InterruptErr
+		}
+		return write(v)
+	case <-session.done:
+		return terminal.InterruptErr
+	}
+}
+
+// note prints a message of a wizard step, which the terminal UI lists with
+// the answers and scripts record in their transcript.
+func note(a ...interface{}) {
+	if session != nil {
+		session.send(noteMsg(strings.TrimSpace(fmt.Sprintln(a...))))
+		return
+	}
+	if script != nil {
+		fmt.Fprintln(&script.Transcript, a...)
+		return
+	}
+	fmt.Fprintln(Out, a...)
+}
+
+type tuiModel struct {
+	width, height int
+
+	steps   []string
+	current int
+	// answers lists the questions answered and notes of the steps.
+	answers []string
+	preview string
+	// scroll is the first preview line shown.
+	scroll int
+
+	question *questionMsg
+	// input is the text typed, the filter of selections.
+	input   string
+	cursor  int
+	checked map[int]bool
+	err     string
+}
+
+var (
+	paneStyle   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+	titleStyle  = lipgloss.NewStyle().Bold(true)
+	faintStyle  = lipgloss.NewStyle().Faint(true)
+	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	activeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true)
+)
+
+func (m *tuiModel) Init() tea.Cmd { return nil }
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case stepMsg:
+		m.steps, m.current = msg.titles, msg.current
+		m.answers = append(m.answers, titleStyle.Render(m.steps[m.current]))
+	case noteMsg:
+		m.answers = append(m.answers, "! "+string(msg))
+	case questionMsg:
+		m.question = &msg
+		m.preview, m.scroll = msg.preview, 0
+		m.input, m.cursor, m.err = "", 0, ""
+		m.checked = map[int]bool{}
+		if ms, ok := msg.prompt.(*survey.MultiSelect); ok {
+			for _, v := range multiSelectDefault(ms) {
+				if i := indexOf(ms.Options, v); i >= 0 {
+					m.checked[i] = true
+				}
+			}
+		}
+		if s, ok := msg.prompt.(*survey.Select); ok {
+			m.cursor = indexOf(s.Options, selectDefault(s))
+		}
+	case wizardDoneMsg:
+		return m, tea.Quit
+	case tea.KeyMsg:
+		return m.key(msg)
+	}
+	return m, nil
+}
+
+func (m *tuiModel) key(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if m.question != nil {
+			close(m.question.reply)
+		}
+		return m, tea.Quit
+	case "pgdown":
+		m.scroll += m.paneHeight() / 2
+		return m, nil
+	case "pgup":
+		m.scroll = clamp(m.scroll-m.paneHeight()/2, 0, m.scroll)
+		return m, nil
+	}
+	if m.question == nil {
+		return m, nil
+	}
+
+	visible := m.visible()
+	switch msg.String() {
+	case "up":
+		m.cursor = clamp(m.cursor-1, 0, m.cursor)
+	case "down":
+		m.cursor = clamp(m.cursor+1, 0, len(visible)-1)
+	case "backspace":
+		if m.input != "" {
+			r := []rune(m.input)
+			m.input, m.cursor = string(r[:len(r)-1]), 0
+		}
+	case " ":
+		if _, ok := m.question.prompt.(*survey.MultiSelect); ok {
+			if m.cursor < len(visible) {
+				m.checked[visible[m.cursor]] = !m.checked[visible[m.cursor]]
+			}
+			break
+		}
+		m.input += " "
+	case "enter":
+		m.submit(visible)
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.input += string(msg.Runes)
+			m.cursor = 0
+		}
+	}
+	return m, nil
+}
+
+// visible returns the indexes of the options matching the filter.
+func (m *tuiModel) visible() []int {
+	var options []string
+	switch p := m.question.prompt.(type) {
+	case *survey.Select:
+		options = p.Options
+	case *survey.MultiSelect:
+		options = p.Options
+	}
+	var visible []int
+	for i, o := range options {
+		if fuzzyMatch(m.input, o, i) {
+			visible = append(visible, i)
+		}
+	}
+	m.cursor = clamp(m.cursor, 0, len(visible)-1)
+	return visible
+}
+
+// submit validates the answer and hands it to the wizard.
+func (m *tuiModel) submit(visible []int) {
+	q := m.question
+	var v interface{}
+	var err error
+	switch p := q.prompt.(type) {
+	case *survey.Select:
+		if len(visible) == 0 {
+			err = fmt.Errorf("no option matches %q", m.input)
+			break
+		}
+		v = core.OptionAnswer{Value: p.Options[visible[m.cursor]], Index: visible[m.cursor]}
+	case *survey.MultiSelect:
+		answers := []core.OptionAnswer{}
+		for i, o := range p.Options {
+			if m.checked[i] {
+				answers = append(answers, core.OptionAnswer{Value: o, Index: i})
+			}
+		}
+		v = answers
+	default:
+		v, err = plainAnswer(q.prompt, m.input)
+	}
+	for _, validate := range q.validators {
+		if err == nil {
+			err = validate(v)
+		}
+	}
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+	message, _, _ := plainPrompt(q.prompt)
+	m.answers = append(m.answers, "  "+message+" "+tuiAnswer(v))
+	m.question = nil
+	q.reply <- v
+}
+
+func tuiAnswer(v interface{}) string {
+	switch v := v.(type) {
+	case core.OptionAnswer:
+		return v.Value
+	case []core.OptionAnswer:
+		values := make([]string, len(v))
+		for i, o := range v {
+			values[i] = o.Value
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(v)
+}
+
+// paneHeight is the number of lines the panes show above the question.
+func (m *tuiModel) paneHeight() int {
+	return clamp(m.height-lipgloss.Height(m.questionView())-2, 3, m.height)
+}
+
+func (m *tuiModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+	h := m.paneHeight()
+
+	var steps []string
+	for i, title := range m.steps {
+		switch {
+		case i < m.current:
+			steps = append(steps, "✓ "+title)
+		case i == m.current:
+			steps = append(steps, activeStyle.Render("> "+title))
+		default:
+			steps = append(steps, "  "+title)
+		}
+	}
+	stepsWidth := 24
+	answersWidth := (m.width - stepsWidth) / 3
+	previewWidth := m.width - stepsWidth - answersWidth - 6
+
+	answers := m.answers
+	if len(answers) > h {
+		answers = answers[len(answers)-h:]
+	}
+	preview := strings.Split(m.preview, "\n")
+	m.scroll = clamp(m.scroll, 0, len(preview)-1)
+	preview = preview[m.scroll:]
+	if len(preview) > h {
+		preview = preview[:h]
+	}
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		pane(steps, stepsWidth-2, h),
+		pane(answers, answersWidth, h),
+		pane(preview, previewWidth, h),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, panes, m.questionView())
+}
+
+// clamp limits v to [lo, hi], preferring lo when hi < lo.
+func clamp(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
+
+// pane boxes lines, cut to width.
+func pane(lines []string, width, height int) string {
+	lines = append([]string(nil), lines...)
+	for i, line := range lines {
+		if r := []rune(line); len(r) > width && !strings.Contains(line, "\x1b") {
+			lines[i] = string(r[:width-1]) + "…"
+		}
+	}
+	return paneStyle.Width(width).Height(height).Render(strings.Join(lines, "\n"))
+}
+
+func (m *tuiModel) questionView() string {
+	if m.question == nil {
+		return faintStyle.Render("Working…  pgup/pgdown scroll the preview, ctrl+c quits")
+	}
+	message, help, hint := plainPrompt(m.question.prompt)
+	switch m.question.prompt.(type) {
+	case *survey.Select:
+		hint = "↑/↓ to move, type to filter, enter to choose"
+	case *survey.MultiSelect:
+		hint = "↑/↓ to move, space to toggle, type to filter, enter to accept"
+	}
+	lines := []string{titleStyle.Render(message) + " " + faintStyle.Render("["+hint+"]")}
+	switch p := m.question.prompt.(type) {
+	case *survey.Select, *survey.MultiSelect:
+		var options []string
+		if s, ok := p.(*survey.Select); ok {
+			options = s.Options
+		} else {
+			options = p.(*survey.MultiSelect).Options
+		}
+		lines = append(lines, "Filter: "+m.input)
+		visible := m.visible()
+		start := clamp(m.cursor-4, 0, len(visible)-8)
+		end := clamp(start+8, 0, len(visible))
+		for k, i := range visible[start:end] {
+			line := "  "
+			if _, multi := p.(*survey.MultiSelect); multi {
+				line = "[ ] "
+				if m.checked[i] {
+					line = "[x] "
+				}
+			}
+			if start+k == m.cursor {
+				line = activeStyle.Render("> " + line + options[i])
+			} else {
+				line = "  " + line + options[i]
+			}
+			lines = append(lines, line)
+		}
+	case *survey.Password:
+		lines = append(lines, "> "+strings.Repeat("*", len([]rune(m.input))))
+	default:
+		lines = append(lines, "> "+m.input)
+	}
+	if help != "" {
+		lines = append(lines, faintStyle.Render(help))
+	}
+	if m.err != "" {
+		lines = append(lines, errorStyle.Render("Invalid answer: "+m.err))
+	}
+	return strings.Join(lines, "\n")
+}
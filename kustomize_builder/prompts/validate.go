@@ -0,0 +1,243 @@
+package prompts
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The validators below check prompt answers as they are typed, so mistakes
+// are reported inline instead of surfacing as broken YAML later.
+
+func answer(ans interface{}) string {
+	s, _ := ans.(string)
+	return strings.TrimSpace(s)
+}
+
+func validationError(value string, msgs []string) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%q: %s", value, strings.Join(msgs, "; "))
+}
+
+// Optional accepts an empty answer and validates anything else with v.
+func Optional(v survey.Validator) survey.Validator {
+	return func(ans interface{}) error {
+		if answer(ans) == "" {
+			return nil
+		}
+		return v(ans)
+	}
+}
+
+// ValidateDNSLabel accepts DNS-1123 labels such as namespace names.
+func ValidateDNSLabel(ans interface{}) error {
+	s := answer(ans)
+	return validationError(s, validation.IsDNS1123Label(s))
+}
+
+// ValidateDNSSubdomain accepts DNS-1123 subdomains such as most object names.
+func ValidateDNSSubdomain(ans interface{}) error {
+	s := answer(ans)
+	return validationError(s, validation.IsDNS1123Subdomain(s))
+}
+
+// ValidateHostname accepts DNS subdomains, optionally with a leading
+// wildcard label such as *.example.com.
+func ValidateHostname(ans interface{}) error {
+	s := answer(ans)
+	if strings.Contains(strings.TrimPrefix(s, "*."), "*") {
+		return fmt.Errorf("%q: only a leading *. wildcard is allowed", s)
+	}
+	return ValidateDNSSubdomain(strings.TrimPrefix(s, "*."))
+}
+
+// listOf accepts comma separated lists of values accepted by v.
+func listOf(v survey.Validator) survey.Validator {
+	return func(ans interface{}) error {
+		for _, value := range splitList(answer(ans)) {
+			if err := v(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// validateHostnames accepts a comma separated list of hostnames.
+var validateHostnames = listOf(ValidateHostname)
+
+// ValidatePort accepts port numbers from 1 to 65535.
+func ValidatePort(ans interface{}) error {
+	s := answer(ans)
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a port number", s)
+	}
+	return validationError(s, validation.IsValidPortNum(port))
+}
+
+// ValidateCIDR accepts IPv4 and IPv6 CIDR ranges.
+func ValidateCIDR(ans interface{}) error {
+	s := answer(ans)
+	if _, _, err := net.ParseCIDR(s); err != nil {
+		return fmt.Errorf("%q is not a CIDR range like 10.0.0.0/8", s)
+	}
+	return nil
+}
+
+// ValidateLabelKey accepts label and annotation keys, optionally prefixed
+// with a DNS subdomain.
+func ValidateLabelKey(ans interface{}) error {
+	s := answer(ans)
+	return validationError(s, validation.IsQualifiedName(s))
+}
+
+// ValidateLabelValue accepts label values.
+func ValidateLabelValue(ans interface{}) error {
+	s := answer(ans)
+	return validationError(s, validation.IsValidLabelValue(s))
+}
+
+// ValidateLabel accepts a KEY=VALUE label.
+func ValidateLabel(ans interface{}) error {
+	key, value, ok := strings.Cut(answer(ans), "=")
+	if !ok {
+		return fmt.Errorf("label must be of the form KEY=VALUE")
+	}
+	if err := ValidateLabelKey(key); err != nil {
+		return err
+	}
+	return ValidateLabelValue(value)
+}
+
+// ValidateQuantity accepts positive resource quantities such as 250m or 512Mi.
+func ValidateQuantity(ans interface{}) error {
+	s := answer(ans)
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return fmt.Errorf("%q is not a resource quantity like 250m or 512Mi", s)
+	}
+	if q.Sign() <= 0 {
+		return fmt.Errorf("%q must be positive", s)
+	}
+	return nil
+}
+
+// ValidatePositiveInt accepts integers greater than zero.
+func ValidatePositiveInt(ans interface{}) error {
+	s := answer(ans)
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return fmt.Errorf("%q is not a positive integer", s)
+	}
+	return nil
+}
+
+// validateNonNegativeInt accepts integers from zero, such as indexes.
+func validateNonNegativeInt(ans interface{}) error {
+	s := answer(ans)
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return fmt.Errorf("%q is not a non-negative integer", s)
+	}
+	return nil
+}
+
+// cronFields are the fields of a cron schedule with their ranges and, for
+// months and weekdays, the names allowed in place of numbers.
+var cronFields = []struct {
+	name     string
+	min, max int
+	names    []string
+}{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{"day of week", 0, 7, []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// cronMacros are the schedules CronJobs accept in place of the five fields.
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// ValidateCronSchedule accepts CronJob schedules: five fields of numbers,
+// ranges, steps and lists, such as */15 9-17 * * mon-fri, or a macro like
+// @daily.
+func ValidateCronSchedule(ans interface{}) error {
+	s := answer(ans)
+	if strings.HasPrefix(s, "@") {
+		for _, macro := range cronMacros {
+			if s == macro {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", s, strings.Join(cronMacros, ", "))
+	}
+	fields := strings.Fields(s)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("%q has %d fields, cron schedules have 5: minute hour day-of-month month day-of-week", s, len(fields))
+	}
+	for i, field := range fields {
+		f := cronFields[i]
+		value := func(v string) (int, error) {
+			for n, name := range f.names {
+				if strings.EqualFold(v, name) {
+					return n + f.min, nil
+				}
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil || n < f.min || n > f.max {
+				return 0, fmt.Errorf("%q: %s must be from %d to %d", s, f.name, f.min, f.max)
+			}
+			return n, nil
+		}
+		for _, item := range strings.Split(field, ",") {
+			span, step, stepped := strings.Cut(item, "/")
+			if stepped {
+				if n, err := strconv.Atoi(step); err != nil || n < 1 {
+					return fmt.Errorf("%q: step %q of the %s is not a positive integer", s, step, f.name)
+				}
+			}
+			if span == "*" {
+				continue
+			}
+			from, to, ranged := strings.Cut(span, "-")
+			start, err := value(from)
+			if err != nil {
+				return err
+			}
+			if !ranged {
+				continue
+			}
+			end, err := value(to)
+			if err != nil {
+				return err
+			}
+			if start > end {
+				return fmt.Errorf("%q: %s range %s is reversed", s, f.name, span)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNameAffix accepts a namePrefix or nameSuffix that keeps generated
+// names valid.
+func validateNameAffix(ans interface{}) error {
+	s := strings.Trim(answer(ans), "-")
+	if s == "" {
+		return nil
+	}
+	return validationError(answer(ans), validation.IsDNS1123Label(s))
+}
+
+func validateConfigMapKey(key string) error {
+	return validationError(key, validation.IsConfigMapKey(key))
+}
@@ -0,0 +1,47 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+func TestValidators(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		validate survey.Validator
+		valid    []string
+		invalid  []string
+	}{
+		{"DNS label", ValidateDNSLabel, []string{"shop", "shop-2", " shop "}, []string{"", "Shop", "shop.example", "-shop", "a_b"}},
+		{"DNS subdomain", ValidateDNSSubdomain, []string{"shop", "shop.example.com"}, []string{"", "Shop", "shop_1", "shop..example"}},
+		{"hostname", ValidateHostname, []string{"shop.example.com", "*.example.com"}, []string{"shop.*.example.com", "**.example.com", "exa mple.com"}},
+		{"hostnames", validateHostnames, []string{"", "a.example.com, *.b.example.com"}, []string{"a.example.com,b_c"}},
+		{"port", ValidatePort, []string{"1", "8080", "65535"}, []string{"0", "65536", "http", ""}},
+		{"CIDR", ValidateCIDR, []string{"10.0.0.0/8", "fd00::/8"}, []string{"10.0.0.0", "10.0.0.0/33", "example"}},
+		{"label key", ValidateLabelKey, []string{"team", "example.com/team"}, []string{"", "-team", "a/b/c"}},
+		{"label value", ValidateLabelValue, []string{"", "shop", "v1.2"}, []string{"-shop", "a b"}},
+		{"label", ValidateLabel, []string{"team=shop", "example.com/tier="}, []string{"team", "=shop", "team=a b"}},
+		{"quantity", ValidateQuantity, []string{"250m", "512Mi", "1"}, []string{"0", "-1", "1 GB", ""}},
+		{"positive int", ValidatePositiveInt, []string{"1", "42"}, []string{"0", "-3", "1.5", ""}},
+		{"non-negative int", validateNonNegativeInt, []string{"0", "7"}, []string{"-1", "x"}},
+		{"optional port", Optional(ValidatePort), []string{"", "  ", "443"}, []string{"https"}},
+		{
+			"cron schedule", ValidateCronSchedule,
+			[]string{"*/15 * * * *", "0 9-17 * * mon-fri", "30 3 1,15 jan,jul 0", "@daily"},
+			[]string{"* * * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "0 0 * 13 *", "0 0 * * 8", "*/0 * * * *", "0 17-9 * * *", "@often"},
+		},
+		{"name affix", validateNameAffix, []string{"", "team-", "-v2", "a"}, []string{"Team-", "team_"}},
+	} {
+		for _, s := range tc.valid {
+			if err := tc.validate(s); err != nil {
+				t.Errorf("%s %q: %v", tc.name, s, err)
+			}
+		}
+		for _, s := range tc.invalid {
+			if err := tc.validate(s); err == nil {
+				t.Errorf("%s %q: accepted", tc.name, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// Drift is a difference between a file on disk and what its answers file
+// renders.
+type Drift struct {
+	Path    string
+	Problem string
+	// Diff is a unified diff of the file on disk against the rendered one,
+	// for modified files.
+	Diff string
+}
+
+func (d Drift) String() string {
+	if d.Diff != "" {
+		return fmt.Sprintf("%s: %s\n%s", d.Path, d.Problem, d.Diff)
+	}
+	return d.Path + ": " + d.Problem
+}
+
+// RenderAnswers renders the tree the answers file at path declares, for the
+// kustomize version named kustomize, the file's or the latest when empty,
+// and in the file's namespace. Only the modules the file lists run, with the
+// prompts they ask while generating answered from the file or taking their
+// defaults. The other wizard steps, such as resources, patches and sizing,
+// are not replayed, so the tree's drift in what they set goes undetected.
+func RenderAnswers(path, kustomize string) ([]File, error) {
+	a, err := LoadAnswers(path)
+	if err != nil {
+		return nil, err
+	}
+	if a.App == "" || len(a.Environments) == 0 {
+		return nil, fmt.Errorf("%s: app and environments are needed to render the tree", path)
+	}
+	s := &State{Layout: NewLayout("", nil)}
+	a.Apply(s)
+	if kustomize != "" {
+		v, err := FindKustomizeVersion(kustomize)
+		if err != nil {
+			return nil, err
+		}
+		s.Layout.Kustomize = v.Name
+	}
+	var steps []Step
+	for _, m := range Modules() {
+		if _, ok := a.module(m.Name()); ok {
+			steps = append(steps, ModuleStep(m))
+		}
+	}
+	return (&Script{Answers: a.Prompts, Defaults: true}).Wizard(s, steps)
+}
+
+// Verify compares the files below dir with the rendered files. Generated
+// files must match, while kustomizations only need to still list what the
+// rendered ones do: the answers file does not declare the fields the other
+// wizard steps set.
+func Verify(dir string, files []File) ([]Drift, error) {
+	var drifts []Drift
+	for _, f := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		if os.IsNotExist(err) {
+			drifts = append(drifts, Drift{Path: f.Path, Problem: "missing"})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if path.Base(f.Path) == "kustomization.yaml" {
+			missing, err := missingEntries(existing, f.Content)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Path, err)
+			}
+			for _, m := range missing {
+				drifts = append(drifts, Drift{Path: f.Path, Problem: m})
+			}
+			continue
+		}
+		if bytes.Equal(existing, f.Content) {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			B:        difflib.SplitLines(string(f.Content)),
+			FromFile: "a/" + f.Path,
+			ToFile:   "b/" + f.Path,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, Drift{Path: f.Path, Problem: "modified", Diff: diff})
+	}
+	return drifts, nil
+}
+
+// missingEntries returns the entries of the rendered kustomization that the
+// existing one no longer has.
+func missingEntries(existing, rendered []byte) ([]string, error) {
+	var have, want Kustomization
+	if err := yaml.Unmarshal(existing, &have); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(rendered, &want); err != nil {
+		return nil, err
+	}
+	var missing []string
+	check := func(field string, have, want []string) {
+		for _, entry := range want {
+			if !containsString(have, entry) {
+				missing = append(missing, fmt.Sprintf("%s no longer lists %s", field, entry))
+			}
+		}
+	}
+	check("resources", have.Resources, want.Resources)
+	check("bases", have.Bases, want.Bases)
+	check("components", have.Components, want.Components)
+	check("patches", patchPaths(have.Patches), patchPaths(want.Patches))
+	check("patchesStrategicMerge", have.PatchesStrategicMerge, want.PatchesStrategicMerge)
+	var havePaths, wantPaths []string
+	for _, p := range have.PatchesJSON6902 {
+		havePaths = append(havePaths, p.Path)
+	}
+	for _, p := range want.PatchesJSON6902 {
+		wantPaths = append(wantPaths, p.Path)
+	}
+	check("patchesJson6902", havePaths, wantPaths)
+	return missing, nil
+}
+
+func patchPaths(patches []Patch) []string {
+	var paths []string
+	for _, p := range patches {
+		if p.Path != "" {
+			paths = append(paths, p.Path)
+		} else {
+			paths = append(paths, strings.TrimSpace(p.Patch))
+		}
+	}
+	return paths
+}
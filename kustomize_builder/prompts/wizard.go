@@ -0,0 +1,185 @@
+package prompts
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// State is what the wizard steps fill in.
+type State struct {
+	OutDir string
+	Layout *Layout
+	// Skip holds the titles of steps already answered, e.g. by a preset.
+	Skip map[string]bool
+	// Answers, when set, answers the module steps it lists.
+	Answers *AnswersFile
+	// Given records the answers the module steps were given, by module,
+	// and those to the prompts they asked while generating, by message.
+	Given AnswersFile
+}
+
+// BaseDir returns the directory the base kustomization is written to.
+func (s *State) BaseDir() string {
+	return filepath.Join(s.OutDir, BaseDir)
+}
+
+// Clone returns a deep copy of s, used to undo a step.
+func (s *State) Clone() *State {
+	c := &State{OutDir: s.OutDir, Layout: s.Layout.Clone(), Skip: map[string]bool{}, Answers: s.Answers}
+	for title, skip := range s.Skip {
+		c.Skip[title] = skip
+	}
+	c.Given.Modules = cloneMap(s.Given.Modules)
+	c.Given.Prompts = cloneMap(s.Given.Prompts)
+	return c
+}
+
+// Step is one page of the wizard.
+type Step struct {
+	Title string
+	// When reports whether the step applies given the earlier answers. A nil
+	// When always applies.
+	When func(s *State) bool
+	Run  func(s *State) error
+}
+
+func (st Step) applies(s *State) bool {
+	if s.Skip[st.Title] {
+		return false
+	}
+	return st.When == nil || st.When(s)
+}
+
+// Wizard runs steps in order, letting the user go back to a previous step or
+// redo the current one. Going back undoes everything the later steps did.
+// Interactive runs end with a summary to confirm, or to jump back from to
+// any completed step.
+type Wizard struct {
+	Steps []Step
+}
+
+const (
+	navNext = "Next"
+	navBack = "Back"
+	navRedo = "Redo this step"
+
+	summaryConfirm = "Confirm"
+	summaryChange  = "Change a step"
+	summaryAbort   = "Abort"
+)
+
+func (w *Wizard) Run(s *State) error {
+	if TUI && session == nil && script == nil && Interactive() {
+		return runTUI(func() error { return w.Run(s) })
+	}
+
+	// done holds the completed steps and the state before each of them.
+	type completed struct {
+		index  int
+		before *State
+	}
+	var done []completed
+
+	for i := 0; ; {
+		if i == len(w.Steps) {
+			if !Interactive() {
+				return nil
+			}
+			summary, err := Summary(s.Layout)
+			if err != nil {
+				return err
+			}
+			if session != nil {
+				session.summary = summary
+			} else if script != nil {
+				fmt.Fprint(&script.Transcript, "\n", summary, "\n")
+			} else {
+				fmt.Fprint(Out, "\n", summary, "\n")
+			}
+			var choice string
+			err = askOne(&survey.Select{
+				Message: "Generate these files?",
+				Options: []string{summaryConfirm, summaryChange, summaryAbort},
+			}, &choice)
+			if err != nil {
+				return err
+			}
+			switch choice {
+			case summaryConfirm:
+				return nil
+			case summaryAbort:
+				return fmt.Errorf("aborted")
+			}
+			titles := make([]string, len(done))
+			for k, d := range done {
+				titles[k] = w.Steps[d.index].Title
+			}
+			var k int
+			if err := askOne(&survey.Select{Message: "Step to change:", Options: titles}, &k); err != nil {
+				return err
+			}
+			*s = *done[k].before
+			i = done[k].index
+			done = done[:k]
+			continue
+		}
+
+		step := w.Steps[i]
+		if !step.applies(s) {
+			i++
+			continue
+		}
+
+		titles := w.titles(i, s)
+		if session != nil {
+			for k := len(done) - 1; k >= 0; k-- {
+				titles = append([]string{w.Steps[done[k].index].Title}, titles...)
+			}
+			session.state = s
+			session.send(stepMsg{titles: titles, current: len(done)})
+		} else {
+			note(fmt.Sprintf("\n[%d/%d] %s", len(done)+1, len(done)+len(titles), step.Title))
+		}
+		before := s.Clone()
+		if err := step.Run(s); err != nil {
+			return fmt.Errorf("%s: %w", step.Title, err)
+		}
+
+		nav := navNext
+		if Interactive() {
+			options := []string{navNext, navRedo}
+			if len(done) > 0 {
+				options = []string{navNext, navBack, navRedo}
+			}
+			if err := askOne(&survey.Select{Message: "Continue:", Options: options, Default: navNext}, &nav); err != nil {
+				return err
+			}
+		}
+
+		switch nav {
+		case navNext:
+			done = append(done, completed{i, before})
+			i++
+		case navBack:
+			prev := done[len(done)-1]
+			done = done[:len(done)-1]
+			*s = *prev.before
+			i = prev.index
+		case navRedo:
+			*s = *before
+		}
+	}
+}
+
+// titles returns the titles of the steps from i on that apply to s.
+func (w *Wizard) titles(i int, s *State) []string {
+	var titles []string
+	for _, st := range w.Steps[i:] {
+		if st.applies(s) {
+			titles = append(titles, st.Title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,497 @@
+package prompts
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// Workload kinds the Workload module offers.
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindCronJob     = "CronJob"
+	KindJob         = "Job"
+)
+
+// workloadKinds returns the kinds of the workloads the base lists, generated
+// or read from baseDir, or Deployment when none can be read.
+func workloadKinds(l *Layout, baseDir string) ([]string, error) {
+	ids, err := ReadResourceIDs(baseDir, l.Base.Resources, l.Generated(BaseDir))
+	if err != nil {
+		return nil, err
+	}
+	var kinds []string
+	for _, kind := range []string{KindDeployment, KindStatefulSet, KindCronJob, KindJob} {
+		for _, id := range ids {
+			if id.Kind == kind {
+				kinds = append(kinds, kind)
+				break
+			}
+		}
+	}
+	if len(kinds) == 0 {
+		kinds = []string{KindDeployment}
+	}
+	return kinds, nil
+}
+
+// runsReplicas reports whether workloads of kind keep replicas running,
+// rather than running pods to completion.
+func runsReplicas(kind string) bool {
+	return kind == KindDeployment || kind == KindStatefulSet
+}
+
+// podSpecPatch returns a patch setting podSpec in the pod template of the
+// workloads of kind, for a patch that targets them by kind.
+func podSpecPatch(kind string, podSpec map[string]interface{}) map[string]interface{} {
+	apiVersion := "apps/v1"
+	spec := map[string]interface{}{"template": map[string]interface{}{"spec": podSpec}}
+	switch kind {
+	case KindCronJob:
+		apiVersion = "batch/v1"
+		spec = map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": spec}}
+	case KindJob:
+		apiVersion = "batch/v1"
+	}
+	return map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": "not-used"},
+		"spec":       spec,
+	}
+}
+
+// Health probes the Deployment and StatefulSet modules offer.
+const (
+	probeHTTP = "HTTP GET"
+	probeTCP  = "TCP socket"
+	probeNone = "None"
+)
+
+// Workload is the app's generated workload. The module of its kind asks for
+// the kind-specific options and generates the manifests.
+type Workload struct {
+	Kind  string
+	Image string
+	// Env are the container's environment variables as KEY=VALUE.
+	Env []string
+}
+
+// workloadModule bootstraps a new app, for apps without manifests to wrap,
+// by choosing the kind of workload and its container.
+type workloadModule struct{}
+
+func (workloadModule) Name() string { return "Workload" }
+
+func (workloadModule) OptIn() string { return "Generate a workload for the app?" }
+
+// validateImage accepts image references with a tag other than latest, or a
+// digest, which pin what is deployed.
+func validateImage(ans interface{}) error {
+	s := answer(ans)
+	name, tag := splitImage(s)
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("%q is not an image reference", s)
+	}
+	if tag == "" || tag == "latest" {
+		return fmt.Errorf("%q has no fixed tag or digest, e.g. %s:1.0.0", s, name)
+	}
+	return validateTagOrDigest(tag)
+}
+
+func (workloadModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name: "kind",
+			Prompt: &survey.Select{
+				Message: "Workload type:",
+				Options: []string{KindDeployment, KindStatefulSet, KindCronJob, KindJob},
+			},
+		},
+		{
+			Name:     "image",
+			Prompt:   &survey.Input{Message: "Container image:", Help: "With a tag or digest, e.g. registry.example.com/shop:1.0.0."},
+			Validate: survey.ComposeValidators(survey.Required, validateImage),
+		},
+		{
+			Name:     "env",
+			Prompt:   &survey.Input{Message: "Environment variables KEY=VALUE, comma separated (optional):"},
+			Validate: listOf(validateLiteral),
+		},
+	}
+}
+
+func (workloadModule) Generate(answers Answers) ([]File, error) {
+	answers.Layout.Workload = Workload{
+		Kind:  answers.String("kind"),
+		Image: answers.String("image"),
+		Env:   splitList(answers.String("env")),
+	}
+	return nil, nil
+}
+
+// container returns the app's container with the workload's image and
+// environment, and conservative resources and security settings.
+func (w Workload) container(app string) map[string]interface{} {
+	container := map[string]interface{}{
+		"name":  app,
+		"image": w.Image,
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"cpu": "100m", "memory": "128Mi"},
+			"limits":   map[string]interface{}{"cpu": "500m", "memory": "512Mi"},
+		},
+		"securityContext": map[string]interface{}{
+			"runAsNonRoot":             true,
+			"allowPrivilegeEscalation": false,
+		},
+	}
+	var env []interface{}
+	for _, literal := range w.Env {
+		key, value, _ := strings.Cut(literal, "=")
+		env = append(env, map[string]interface{}{"name": key, "value": value})
+	}
+	if len(env) > 0 {
+		container["env"] = env
+	}
+	return container
+}
+
+// serverQuestions ask for the port and probes of workloads serving traffic.
+func serverQuestions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:     "port",
+			Prompt:   &survey.Input{Message: "Container port:", Default: "8080"},
+			Validate: ValidatePort,
+		},
+		{
+			Name: "probe",
+			Prompt: &survey.Select{
+				Message: "Health probes:",
+				Options: []string{probeHTTP, probeTCP, probeNone},
+			},
+		},
+		{
+			Name:     "probePath",
+			Prompt:   &survey.Input{Message: "HTTP probe path:", Default: "/healthz"},
+			Validate: validateIngressPath,
+		},
+	}
+}
+
+// asksServer reports whether the server question name is asked: the probe
+// path is only asked for HTTP probes.
+func asksServer(name string, answers Answers) bool {
+	return name != "probePath" || answers.String("probe") == probeHTTP
+}
+
+// serverContainer returns the app's container listening on the answered
+// port, named http, with the answered probes.
+func serverContainer(answers Answers) (map[string]interface{}, error) {
+	l := answers.Layout
+	port, err := strconv.Atoi(answers.String("port"))
+	if err != nil {
+		return nil, err
+	}
+	container := l.Workload.container(l.App)
+	container["ports"] = []interface{}{
+		map[string]interface{}{"name": "http", "containerPort": port},
+	}
+	var check map[string]interface{}
+	switch answers.String("probe") {
+	case probeHTTP:
+		check = map[string]interface{}{"httpGet": map[string]interface{}{"path": answers.String("probePath"), "port": "http"}}
+	case probeTCP:
+		check = map[string]interface{}{"tcpSocket": map[string]interface{}{"port": "http"}}
+	}
+	if check != nil {
+		readiness := map[string]interface{}{"initialDelaySeconds": 5, "periodSeconds": 10}
+		liveness := map[string]interface{}{"initialDelaySeconds": 15, "periodSeconds": 20}
+		for key, value := range check {
+			readiness[key], liveness[key] = value, value
+		}
+		container["readinessProbe"], container["livenessProbe"] = readiness, liveness
+	}
+	return container, nil
+}
+
+// service returns the app's Service listening on port 80, which the routing
+// backends send traffic to.
+func service(app string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": app, "labels": appLabels(app)},
+		"spec": map[string]interface{}{
+			"selector": appLabels(app),
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "port": 80, "targetPort": "http"},
+			},
+		},
+	}
+}
+
+// podTemplate returns a pod template running container.
+func podTemplate(app string, container map[string]interface{}, restartPolicy string) map[string]interface{} {
+	spec := map[string]interface{}{"containers": []interface{}{container}}
+	if restartPolicy != "" {
+		spec["restartPolicy"] = restartPolicy
+	}
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": appLabels(app)},
+		"spec":     spec,
+	}
+}
+
+// workloadManifest is a generated manifest and its file in the base.
+type workloadManifest struct {
+	file     string
+	manifest map[string]interface{}
+}
+
+// baseResources marshals the manifests into files of the base and adds
+// them to its resources.
+func baseResources(l *Layout, manifests ...workloadManifest) ([]File, error) {
+	var files []File
+	for _, m := range manifests {
+		content, err := marshalYAML(m.manifest)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(BaseDir, m.file), Content: content})
+		l.Base.AddResource(m.file)
+	}
+	return files, nil
+}
+
+// deploymentModule generates a Deployment of the workload with a Service in
+// front of it.
+type deploymentModule struct{}
+
+func (deploymentModule) Name() string { return KindDeployment }
+
+func (deploymentModule) Applies(l *Layout) bool { return l.Workload.Kind == KindDeployment }
+
+func (deploymentModule) Questions() []*survey.Question { return serverQuestions() }
+
+func (deploymentModule) Asks(name string, answers Answers) bool { return asksServer(name, answers) }
+
+func (deploymentModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	container, err := serverContainer(answers)
+	if err != nil {
+		return nil, err
+	}
+	return baseResources(l,
+		workloadManifest{"deployment.yaml", map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": l.App, "labels": appLabels(l.App)},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": appLabels(l.App)},
+				"template": podTemplate(l.App, container, ""),
+			},
+		}},
+		workloadManifest{"service.yaml", service(l.App)},
+	)
+}
+
+// statefulSetModule generates a StatefulSet of the workload with a volume
+// claimed per pod, the headless Service governing it and a Service in front
+// of it.
+type statefulSetModule struct{}
+
+func (statefulSetModule) Name() string { return KindStatefulSet }
+
+func (statefulSetModule) Applies(l *Layout) bool { return l.Workload.Kind == KindStatefulSet }
+
+func (statefulSetModule) Asks(name string, answers Answers) bool { return asksServer(name, answers) }
+
+func (statefulSetModule) Questions() []*survey.Question {
+	return append(serverQuestions(),
+		&survey.Question{
+			Name:     "mountPath",
+			Prompt:   &survey.Input{Message: "Volume mount path:", Default: "/data"},
+			Validate: validateIngressPath,
+		},
+		&survey.Question{
+			Name:     "size",
+			Prompt:   &survey.Input{Message: "Volume size:", Default: "1Gi"},
+			Validate: ValidateQuantity,
+		},
+		&survey.Question{
+			Name:     "storageClass",
+			Prompt:   &survey.Input{Message: "Storage class (optional):"},
+			Validate: Optional(ValidateDNSSubdomain),
+		},
+	)
+}
+
+func (statefulSetModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	container, err := serverContainer(answers)
+	if err != nil {
+		return nil, err
+	}
+	container["volumeMounts"] = []interface{}{
+		map[string]interface{}{"name": "data", "mountPath": answers.String("mountPath")},
+	}
+	claim := map[string]interface{}{
+		"accessModes": []interface{}{"ReadWriteOnce"},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"storage": answers.String("size")},
+		},
+	}
+	if class := answers.String("storageClass"); class != "" {
+		claim["storageClassName"] = class
+	}
+	headless := service(l.App)
+	headless["metadata"] = map[string]interface{}{"name": l.App + "-headless", "labels": appLabels(l.App)}
+	headless["spec"].(map[string]interface{})["clusterIP"] = "None"
+
+	return baseResources(l,
+		workloadManifest{"statefulset.yaml", map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"metadata":   map[string]interface{}{"name": l.App, "labels": appLabels(l.App)},
+			"spec": map[string]interface{}{
+				"serviceName": l.App + "-headless",
+				"selector":    map[string]interface{}{"matchLabels": appLabels(l.App)},
+				"template":    podTemplate(l.App, container, ""),
+				"volumeClaimTemplates": []interface{}{
+					map[string]interface{}{
+						"metadata": map[string]interface{}{"name": "data"},
+						"spec":     claim,
+					},
+				},
+			},
+		}},
+		workloadManifest{"service.yaml", service(l.App)},
+		workloadManifest{"service-headless.yaml", headless},
+	)
+}
+
+// backoffLimitQuestion asks how often a failed job is retried.
+func backoffLimitQuestion() *survey.Question {
+	return &survey.Question{
+		Name:     "backoffLimit",
+		Prompt:   &survey.Input{Message: "Retries before the job fails:", Default: "3"},
+		Validate: validateNonNegativeInt,
+	}
+}
+
+// jobSpec returns the spec of a Job running the workload's container, with
+// the answered retries.
+func jobSpec(answers Answers) (map[string]interface{}, error) {
+	l := answers.Layout
+	backoffLimit, err := strconv.Atoi(answers.String("backoffLimit"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"backoffLimit": backoffLimit,
+		"template":     podTemplate(l.App, l.Workload.container(l.App), "OnFailure"),
+	}, nil
+}
+
+// Concurrency policies of CronJobs.
+const (
+	concurrencyForbid  = "Forbid"
+	concurrencyAllow   = "Allow"
+	concurrencyReplace = "Replace"
+)
+
+// cronJobModule generates a CronJob running the workload on a schedule.
+type cronJobModule struct{}
+
+func (cronJobModule) Name() string { return KindCronJob }
+
+func (cronJobModule) Applies(l *Layout) bool { return l.Workload.Kind == KindCronJob }
+
+func (cronJobModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:     "schedule",
+			Prompt:   &survey.Input{Message: "Schedule (cron):", Default: "0 3 * * *"},
+			Validate: survey.ComposeValidators(survey.Required, ValidateCronSchedule),
+		},
+		{
+			Name: "concurrencyPolicy",
+			Prompt: &survey.Select{
+				Message: "Concurrency policy:",
+				Options: []string{concurrencyForbid, concurrencyAllow, concurrencyReplace},
+				Help:    "Forbid skips a run while the previous one is still running, Replace cancels the previous one.",
+			},
+		},
+		backoffLimitQuestion(),
+	}
+}
+
+func (cronJobModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	job, err := jobSpec(answers)
+	if err != nil {
+		return nil, err
+	}
+	return baseResources(l, workloadManifest{"cronjob.yaml", map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"metadata":   map[string]interface{}{"name": l.App, "labels": appLabels(l.App)},
+		"spec": map[string]interface{}{
+			"schedule":          answers.String("schedule"),
+			"concurrencyPolicy": answers.String("concurrencyPolicy"),
+			"jobTemplate": map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": appLabels(l.App)},
+				"spec":     job,
+			},
+		},
+	}})
+}
+
+// jobModule generates a Job running the workload to completion.
+type jobModule struct{}
+
+func (jobModule) Name() string { return KindJob }
+
+func (jobModule) Applies(l *Layout) bool { return l.Workload.Kind == KindJob }
+
+func (jobModule) Questions() []*survey.Question {
+	return []*survey.Question{
+		{
+			Name:     "completions",
+			Prompt:   &survey.Input{Message: "Completions:", Default: "1"},
+			Validate: ValidatePositiveInt,
+		},
+		{
+			Name:     "parallelism",
+			Prompt:   &survey.Input{Message: "Parallelism:", Default: "1"},
+			Validate: ValidatePositiveInt,
+		},
+		backoffLimitQuestion(),
+	}
+}
+
+func (jobModule) Generate(answers Answers) ([]File, error) {
+	l := answers.Layout
+	job, err := jobSpec(answers)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"completions", "parallelism"} {
+		n, err := strconv.Atoi(answers.String(name))
+		if err != nil {
+			return nil, err
+		}
+		job[name] = n
+	}
+	return baseResources(l, workloadManifest{"job.yaml", map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]interface{}{"name": l.App, "labels": appLabels(l.App)},
+		"spec":       job,
+	}})
+}
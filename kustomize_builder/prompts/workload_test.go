@@ -0,0 +1,28 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProbePathOnlyAskedForHTTPProbes(t *testing.T) {
+	for probe, asked := range map[string]bool{probeHTTP: true, probeTCP: false, probeNone: false} {
+		s := &State{OutDir: t.TempDir(), Layout: NewLayout("shop", []string{"dev"})}
+		sc := &Script{
+			Answers: map[string]string{
+				"Generate a workload for the app?": "y",
+				"Workload type:":                   KindDeployment,
+				"Container image:":                 "registry.example.com/shop:1.0.0",
+				"Health probes:":                   probe,
+			},
+			Defaults: true,
+		}
+		steps := []Step{ModuleStep(workloadModule{}), ModuleStep(deploymentModule{})}
+		if _, err := sc.Wizard(s, steps); err != nil {
+			t.Fatalf("%s: %v", probe, err)
+		}
+		if got := strings.Contains(sc.Transcript.String(), "HTTP probe path:"); got != asked {
+			t.Errorf("%s: probe path asked = %v, want %v\n%s", probe, got, asked, sc.Transcript.String())
+		}
+	}
+}